@@ -0,0 +1,14 @@
+// Command oopsvet reports common misuses of the go.sdls.io/oops/pkg/oops package. It can be run on its own
+// (oopsvet ./...) or through go vet (go vet -vettool=$(which oopsvet) ./...). A report is silenced by a //nolint comment
+// on its line, naming the analyzer or oopsvet.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"go.sdls.io/oops/cmd/oopsvet/passes"
+)
+
+func main() {
+	multichecker.Main(passes.Analyzers...)
+}
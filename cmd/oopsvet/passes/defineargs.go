@@ -0,0 +1,74 @@
+package passes
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// DefineArgs reports calls to Define (or Child) with an odd number of props or with non-string keys, which panic. Prop
+// arguments (see oops.Key) count as a whole key-value pair.
+var DefineArgs = &analysis.Analyzer{
+	Name:     "defineargs",
+	Doc:      "report Define and Child calls with an odd number of props or non-string keys, as they panic",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runDefineArgs,
+}
+
+func runDefineArgs(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector) //nolint:forcetypeassert
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr) //nolint:forcetypeassert
+		if call.Ellipsis.IsValid() {
+			return
+		}
+
+		fn := oopsCallee(pass.TypesInfo, call)
+		if fn == nil {
+			return
+		}
+
+		params := fn.Signature().Params()
+		if !fn.Signature().Variadic() || params.At(params.Len()-1).Name() != "props" {
+			return
+		}
+
+		props := call.Args[params.Len()-1:]
+		for idx := 0; idx < len(props); idx++ {
+			key := pass.TypesInfo.TypeOf(props[idx])
+			if key == nil || isOopsProp(key) {
+				continue
+			}
+
+			if idx+1 == len(props) {
+				reportf(pass, call.Pos(), "%s called with an odd number of props (%d), it panics", fn.Name(), len(props))
+				return
+			}
+
+			if basic, ok := key.Underlying().(*types.Basic); !ok || basic.Info()&types.IsString == 0 {
+				reportf(pass, props[idx].Pos(), "%s key %s must be a string, got %s, it panics",
+					fn.Name(), types.ExprString(props[idx]), key)
+			}
+
+			idx++
+		}
+	})
+
+	return nil, nil //nolint:nilnil
+}
+
+// isOopsProp returns true if the type is oops.Prop.
+func isOopsProp(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == PackagePath && obj.Name() == "Prop"
+}
@@ -0,0 +1,72 @@
+package passes
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// DefinedReturn reports an ErrorDefined returned as an error, as its Error method panics.
+var DefinedReturn = &analysis.Analyzer{
+	Name:     "definedreturn",
+	Doc:      "report ErrorDefined returned as error, as ErrorDefined.Error panics; use Yeet or Wrap instead",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runDefinedReturn,
+}
+
+func runDefinedReturn(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector) //nolint:forcetypeassert
+	errorType := types.Universe.Lookup("error").Type()
+
+	inspect.WithStack([]ast.Node{(*ast.ReturnStmt)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		sig := enclosingSignature(pass.TypesInfo, stack)
+		if sig == nil {
+			return true
+		}
+
+		ret := n.(*ast.ReturnStmt) //nolint:forcetypeassert
+		if len(ret.Results) != sig.Results().Len() {
+			return true
+		}
+
+		for idx, result := range ret.Results {
+			if !types.Identical(sig.Results().At(idx).Type(), errorType) || !isDefined(pass.TypesInfo.TypeOf(result)) {
+				continue
+			}
+
+			reportf(pass, result.Pos(),
+				"ErrorDefined %s returned as error, its Error method panics; use %s.Yeet() or %s.Wrap(err)",
+				types.ExprString(result), types.ExprString(result), types.ExprString(result))
+		}
+
+		return true
+	})
+
+	return nil, nil //nolint:nilnil
+}
+
+func enclosingSignature(info *types.Info, stack []ast.Node) *types.Signature {
+	for idx := len(stack) - 1; idx >= 0; idx-- {
+		switch fn := stack[idx].(type) {
+		case *ast.FuncLit:
+			sig, _ := info.TypeOf(fn).(*types.Signature)
+			return sig
+		case *ast.FuncDecl:
+			obj, _ := info.Defs[fn.Name].(*types.Func)
+			if obj == nil {
+				return nil
+			}
+
+			return obj.Signature()
+		}
+	}
+
+	return nil
+}
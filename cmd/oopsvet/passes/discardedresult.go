@@ -0,0 +1,36 @@
+package passes
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// DiscardedResult reports calls to Error.Append and Error.PathSetf whose result is discarded. Implementations of Error
+// are not required to update the receiver, only the returned Error is guaranteed to be updated.
+var DiscardedResult = &analysis.Analyzer{
+	Name:     "discardedresult",
+	Doc:      "report discarded results of Error.Append and Error.PathSetf",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runDiscardedResult,
+}
+
+func runDiscardedResult(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector) //nolint:forcetypeassert
+
+	inspect.Preorder([]ast.Node{(*ast.ExprStmt)(nil)}, func(n ast.Node) {
+		call, ok := ast.Unparen(n.(*ast.ExprStmt).X).(*ast.CallExpr) //nolint:forcetypeassert
+		if !ok {
+			return
+		}
+
+		switch name, _ := oopsMethod(pass.TypesInfo, call); name {
+		case "Append", "PathSetf":
+			reportf(pass, call.Pos(), "result of %s is discarded, the returned Error must be used", name)
+		}
+	})
+
+	return nil, nil //nolint:nilnil
+}
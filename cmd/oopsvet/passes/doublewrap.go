@@ -0,0 +1,200 @@
+package passes
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// DoubleWrap reports errors wrapped with a definition (using Wrap or Wrapf) right after being yeeted or wrapped with
+// the same definition, such as ErrX.Wrap(ErrX.Wrapf(err, "...")), including through a variable of the same function
+// (such as err = ErrX.Wrap(err) followed by ErrX.Wrap(err)). Variables are only followed within the block they are
+// assigned in (and the blocks it encloses), and until they are assigned again.
+var DoubleWrap = &analysis.Analyzer{
+	Name:     "doublewrap",
+	Doc:      "report errors wrapped twice in a row with the same definition",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runDoubleWrap,
+}
+
+func runDoubleWrap(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector) //nolint:forcetypeassert
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr) //nolint:forcetypeassert
+
+		outer, receiver := definedMethod(pass.TypesInfo, call)
+		if (outer != "Wrap" && outer != "Wrapf") || len(call.Args) == 0 {
+			return
+		}
+
+		inner, ok := ast.Unparen(call.Args[0]).(*ast.CallExpr)
+		if !ok {
+			return
+		}
+
+		if innerReceiver := definedErrorCall(pass.TypesInfo, inner); receiver != nil && receiver == innerReceiver {
+			reportDoubleWrap(pass, call, receiver)
+		}
+	})
+
+	inspect.Preorder([]ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}, func(n ast.Node) {
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			if fn.Body != nil {
+				doubleWrapVars(pass, fn.Body)
+			}
+		case *ast.FuncLit:
+			doubleWrapVars(pass, fn.Body)
+		}
+	})
+
+	return nil, nil //nolint:nilnil
+}
+
+// wrappedVar is a variable assigned an error created by a definition.
+type wrappedVar struct {
+	receiver types.Object
+	block    ast.Node
+}
+
+// doubleWrapVars reports the variables wrapped with the definition of the error they were last assigned, in the body
+// of a function (but not of the functions it declares, which are checked on their own).
+func doubleWrapVars(pass *analysis.Pass, body *ast.BlockStmt) {
+	var (
+		wrapped = make(map[types.Object]wrappedVar)
+		stack   []ast.Node
+	)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			switch last := stack[len(stack)-1].(type) {
+			case *ast.AssignStmt:
+				if len(last.Lhs) == len(last.Rhs) {
+					assignWrapped(pass.TypesInfo, wrapped, stack, last.Lhs, last.Rhs)
+				} else {
+					assignWrapped(pass.TypesInfo, wrapped, stack, last.Lhs, nil)
+				}
+			case *ast.ValueSpec:
+				names := make([]ast.Expr, len(last.Names))
+				for idx, name := range last.Names {
+					names[idx] = name
+				}
+
+				if len(last.Names) == len(last.Values) {
+					assignWrapped(pass.TypesInfo, wrapped, stack, names, last.Values)
+				} else {
+					assignWrapped(pass.TypesInfo, wrapped, stack, names, nil)
+				}
+			case *ast.UnaryExpr:
+				// the variable may be assigned through its address
+				if last.Op == token.AND {
+					assignWrapped(pass.TypesInfo, wrapped, stack, []ast.Expr{last.X}, nil)
+				}
+			}
+
+			stack = stack[:len(stack)-1]
+
+			return true
+		}
+
+		if _, ok := n.(*ast.FuncLit); ok && n != body {
+			return false
+		}
+
+		stack = append(stack, n)
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		outer, receiver := definedMethod(pass.TypesInfo, call)
+		if (outer != "Wrap" && outer != "Wrapf") || len(call.Args) == 0 || receiver == nil {
+			return true
+		}
+
+		ident, ok := ast.Unparen(call.Args[0]).(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		if v, ok := wrapped[pass.TypesInfo.Uses[ident]]; ok && v.receiver == receiver &&
+			v.block.Pos() <= call.Pos() && call.End() <= v.block.End() {
+			reportDoubleWrap(pass, call, receiver)
+		}
+
+		return true
+	})
+}
+
+// assignWrapped records the variables assigned an error created by a definition, given the values assigned to them
+// (nil if unknown), and forgets the other assigned variables.
+func assignWrapped(info *types.Info, wrapped map[types.Object]wrappedVar, stack []ast.Node, lhs, rhs []ast.Expr) {
+	for idx, expr := range lhs {
+		ident, ok := ast.Unparen(expr).(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		obj := info.ObjectOf(ident)
+		if obj == nil {
+			continue
+		}
+
+		delete(wrapped, obj)
+
+		if rhs == nil {
+			continue
+		}
+
+		if call, ok := ast.Unparen(rhs[idx]).(*ast.CallExpr); ok {
+			if receiver := definedErrorCall(info, call); receiver != nil {
+				wrapped[obj] = wrappedVar{receiver: receiver, block: enclosingBlock(stack)}
+			}
+		}
+	}
+}
+
+// enclosingBlock returns the innermost block (or case clause) of the stack.
+func enclosingBlock(stack []ast.Node) ast.Node {
+	for idx := len(stack) - 1; idx >= 0; idx-- {
+		switch stack[idx].(type) {
+		case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+			return stack[idx]
+		}
+	}
+
+	return stack[0]
+}
+
+// definedErrorCall returns the object of the definition creating an error with the call expression (using Yeet,
+// Yeetf, Wrap or Wrapf), if any.
+func definedErrorCall(info *types.Info, call *ast.CallExpr) types.Object {
+	switch name, receiver := definedMethod(info, call); name {
+	case "Yeet", "Yeetf", "Wrap", "Wrapf":
+		return receiver
+	default:
+		return nil
+	}
+}
+
+func reportDoubleWrap(pass *analysis.Pass, call *ast.CallExpr, receiver types.Object) {
+	reportf(pass, call.Pos(), "error of %s wrapped again with %s, use Explainf to add an explanation instead",
+		receiver.Name(), receiver.Name())
+}
+
+// definedMethod returns the name of the ErrorDefined method called by the call expression, and the object of its
+// receiver (if a variable, such as ErrX or pkg.ErrX).
+func definedMethod(info *types.Info, call *ast.CallExpr) (string, types.Object) {
+	name, receiver := oopsMethod(info, call)
+	if receiver == nil || !isDefined(info.TypeOf(receiver)) {
+		return "", nil
+	}
+
+	return name, exprObject(info, receiver)
+}
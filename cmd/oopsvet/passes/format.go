@@ -0,0 +1,323 @@
+package passes
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Format reports format strings (of Yeetf, Wrapf, Explainf, PathSetf or any other oops function taking a format or a
+// path followed by its args) not matching their args: reading another number of args, or args of the wrong type for
+// their verb (as the printf analyzer of go vet does, such as a string given to %d). As formats given without args are
+// used verbatim, they are only reported if they contain a plain verb (such as %s or %d).
+var Format = &analysis.Analyzer{
+	Name:     "oopsformat",
+	Doc:      "report Yeetf, Wrapf, Explainf and PathSetf format strings not matching their args",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runFormat,
+}
+
+func runFormat(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector) //nolint:forcetypeassert
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr) //nolint:forcetypeassert
+		if call.Ellipsis.IsValid() {
+			return
+		}
+
+		fn := oopsCallee(pass.TypesInfo, call)
+		if fn == nil || !fn.Signature().Variadic() || fn.Signature().Params().Len() < 2 {
+			return
+		}
+
+		params := fn.Signature().Params()
+		formatIdx := params.Len() - 2
+		if name := params.At(formatIdx).Name(); name != "format" && name != "path" {
+			return
+		}
+
+		tv, ok := pass.TypesInfo.Types[call.Args[formatIdx]]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			return
+		}
+
+		format := constant.StringVal(tv.Value)
+		args := len(call.Args) - formatIdx - 1
+
+		directives, ok := parseFormat(format)
+		if !ok {
+			return
+		}
+
+		if args == 0 {
+			for _, d := range directives {
+				if d.plain {
+					reportf(pass, call.Pos(), "%s format %q has verbs but no args, it is used verbatim", fn.Name(), format)
+					return
+				}
+			}
+
+			return
+		}
+
+		reads := 0
+		for _, d := range directives {
+			if d.verb == 'w' {
+				reportf(pass, call.Pos(), "%s format %q uses %%w, which is not supported; wrap errors with Wrap or Wrapf",
+					fn.Name(), format)
+				return
+			}
+
+			reads += d.args
+		}
+
+		if reads != args {
+			reportf(pass, call.Pos(), "%s format %q reads %d args, but the call has %d", fn.Name(), format, reads, args)
+			return
+		}
+
+		checkFormatArgs(pass, fn, directives, call.Args[formatIdx+1:])
+	})
+
+	return nil, nil //nolint:nilnil
+}
+
+type formatDirective struct {
+	verb  rune
+	args  int
+	plain bool
+}
+
+// parseFormat returns the directives of a fmt format string. Returns false if the format uses explicit argument
+// indexes, as the number of args read can not be checked.
+func parseFormat(format string) ([]formatDirective, bool) {
+	var directives []formatDirective
+
+	for idx := 0; idx < len(format); idx++ {
+		if format[idx] != '%' {
+			continue
+		}
+
+		idx++
+		if idx < len(format) && format[idx] == '%' {
+			continue
+		}
+
+		d := formatDirective{args: 1, plain: true}
+
+		for idx < len(format) && strings.IndexByte("+-# 0", format[idx]) >= 0 {
+			d.plain = false
+			idx++
+		}
+
+		idx, d = parseFormatNumber(format, idx, d)
+
+		if idx < len(format) && format[idx] == '.' {
+			d.plain = false
+			idx, d = parseFormatNumber(format, idx+1, d)
+		}
+
+		if idx < len(format) && format[idx] == '[' {
+			return nil, false
+		}
+
+		if idx >= len(format) {
+			// a trailing percent sign does not read any arg (printing %!(NOVERB))
+			break
+		}
+
+		var size int
+		d.verb, size = utf8.DecodeRuneInString(format[idx:])
+		d.plain = d.plain && unicode.IsLetter(d.verb)
+		idx += size - 1
+
+		directives = append(directives, d)
+	}
+
+	return directives, true
+}
+
+func parseFormatNumber(format string, idx int, d formatDirective) (int, formatDirective) {
+	if idx < len(format) && format[idx] == '*' {
+		d.args++
+		d.plain = false
+
+		return idx + 1, d
+	}
+
+	for idx < len(format) && format[idx] >= '0' && format[idx] <= '9' {
+		d.plain = false
+		idx++
+	}
+
+	return idx, d
+}
+
+// formatKinds are the kinds of args accepted by a verb.
+type formatKinds uint8
+
+const (
+	formatBool formatKinds = 1 << iota
+	formatInt
+	formatFloat
+	formatComplex
+	formatString
+	formatPointer
+	formatAny
+)
+
+// formatVerbs are the kinds of args accepted by the fmt verbs. Unknown verbs are not checked.
+var formatVerbs = map[rune]formatKinds{
+	'b': formatInt | formatFloat | formatComplex | formatPointer,
+	'c': formatInt,
+	'd': formatInt | formatPointer,
+	'e': formatFloat | formatComplex,
+	'E': formatFloat | formatComplex,
+	'f': formatFloat | formatComplex,
+	'F': formatFloat | formatComplex,
+	'g': formatFloat | formatComplex,
+	'G': formatFloat | formatComplex,
+	'o': formatInt | formatPointer,
+	'O': formatInt | formatPointer,
+	'p': formatPointer,
+	'q': formatInt | formatString,
+	's': formatString,
+	't': formatBool,
+	'T': formatAny,
+	'U': formatInt,
+	'v': formatAny,
+	'x': formatInt | formatFloat | formatComplex | formatString | formatPointer,
+	'X': formatInt | formatFloat | formatComplex | formatString | formatPointer,
+}
+
+// checkFormatArgs reports the args not matching the verb of their directive, given the args read by the directives.
+func checkFormatArgs(pass *analysis.Pass, fn *types.Func, directives []formatDirective, args []ast.Expr) {
+	qualifier := types.RelativeTo(pass.Pkg)
+
+	for _, d := range directives {
+		for ; d.args > 1; d.args-- {
+			if t := pass.TypesInfo.TypeOf(args[0]); t != nil && !formatMatches(formatInt, 0, t) {
+				reportf(pass, args[0].Pos(), "%s format %%%c uses non-int %s of type %s as argument of *", fn.Name(), d.verb,
+					types.ExprString(args[0]), types.TypeString(t, qualifier))
+			}
+
+			args = args[1:]
+		}
+
+		arg := args[0]
+		args = args[1:]
+
+		kinds, ok := formatVerbs[d.verb]
+		if !ok {
+			continue
+		}
+
+		if t := pass.TypesInfo.TypeOf(arg); t != nil && !formatMatches(kinds, d.verb, t) {
+			reportf(pass, arg.Pos(), "%s format %%%c has arg %s of wrong type %s", fn.Name(), d.verb,
+				types.ExprString(arg), types.TypeString(t, qualifier))
+		}
+	}
+}
+
+// formatMatches returns true if fmt formats a value of the type with the verb accepting the given kinds, without
+// printing a bad verb error (such as %!d(string=...)). Composite types match if all their elements do, as fmt formats
+// them element-wise.
+func formatMatches(kinds formatKinds, verb rune, t types.Type) bool {
+	return formatMatchesType(kinds, verb, t, make(map[types.Type]bool), true)
+}
+
+func formatMatchesType(kinds formatKinds, verb rune, t types.Type, seen map[types.Type]bool, top bool) bool {
+	if kinds&formatAny != 0 || hasMethod(t, "Format") {
+		return true
+	}
+
+	if strings.ContainsRune("sqvxX", verb) && (hasMethod(t, "Error") || hasMethod(t, "String")) {
+		return true
+	}
+
+	if seen[t] {
+		return true
+	}
+
+	seen[t] = true
+
+	switch u := t.Underlying().(type) {
+	case *types.Interface:
+		// the dynamic type is unknown (including type parameters)
+		return true
+	case *types.Basic:
+		return formatMatchesBasic(kinds, u)
+	case *types.Slice:
+		return isByte(u.Elem()) && kinds&formatString != 0 || formatMatchesType(kinds, verb, u.Elem(), seen, false)
+	case *types.Array:
+		return isByte(u.Elem()) && kinds&formatString != 0 || formatMatchesType(kinds, verb, u.Elem(), seen, false)
+	case *types.Map:
+		return formatMatchesType(kinds, verb, u.Key(), seen, false) &&
+			formatMatchesType(kinds, verb, u.Elem(), seen, false)
+	case *types.Struct:
+		for field := range u.Fields() {
+			if !formatMatchesType(kinds, verb, field.Type(), seen, false) {
+				return false
+			}
+		}
+
+		return true
+	case *types.Pointer:
+		if kinds&formatPointer != 0 {
+			return true
+		}
+
+		// pointers to composite types are formatted as their element (such as &{...}), unless nested
+		switch u.Elem().Underlying().(type) {
+		case *types.Struct, *types.Array, *types.Slice, *types.Map:
+			return top && formatMatchesType(kinds, verb, u.Elem(), seen, false)
+		default:
+			return false
+		}
+	case *types.Chan, *types.Signature:
+		return kinds&formatPointer != 0
+	default:
+		return true
+	}
+}
+
+func formatMatchesBasic(kinds formatKinds, t *types.Basic) bool {
+	switch info := t.Info(); {
+	case info&types.IsBoolean != 0:
+		return kinds&formatBool != 0
+	case info&types.IsInteger != 0:
+		return kinds&formatInt != 0
+	case info&types.IsFloat != 0:
+		return kinds&formatFloat != 0
+	case info&types.IsComplex != 0:
+		return kinds&formatComplex != 0
+	case info&types.IsString != 0:
+		return kinds&formatString != 0
+	case t.Kind() == types.UnsafePointer:
+		return kinds&formatPointer != 0
+	default:
+		// untyped nil
+		return true
+	}
+}
+
+// hasMethod returns true if the method set of the type has a method with the given name.
+func hasMethod(t types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, false, nil, name)
+	_, ok := obj.(*types.Func)
+
+	return ok
+}
+
+func isByte(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Byte
+}
@@ -0,0 +1,141 @@
+// Package passes provides the analyzers of oopsvet, each reporting a misuse of the go.sdls.io/oops/pkg/oops package
+// that is detectable from its calls.
+package passes
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// PackagePath is the import path of the checked package.
+const PackagePath = "go.sdls.io/oops/pkg/oops"
+
+// Analyzers are all the analyzers of oopsvet.
+var Analyzers = []*analysis.Analyzer{
+	DefinedReturn,
+	DefineArgs,
+	DiscardedResult,
+	DoubleWrap,
+	Format,
+	TypedNil,
+}
+
+// reportf reports the diagnostic at the given position, unless the line holds a //nolint comment (as honored by
+// golangci-lint) naming the analyzer, or oopsvet, or no linter at all.
+func reportf(pass *analysis.Pass, pos token.Pos, format string, args ...any) {
+	if nolint(pass, pos) {
+		return
+	}
+
+	pass.Reportf(pos, format, args...)
+}
+
+// nolint returns true if a //nolint comment on the line of the position disables the analyzer of the pass.
+func nolint(pass *analysis.Pass, pos token.Pos) bool {
+	line := pass.Fset.Position(pos).Line
+
+	for _, file := range pass.Files {
+		if pos < file.FileStart || pos > file.FileEnd {
+			continue
+		}
+
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				if pass.Fset.Position(comment.Slash).Line == line && nolintNames(comment.Text, pass.Analyzer.Name) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// nolintNames returns true if the comment is a //nolint directive for all linters, the analyzer or oopsvet.
+func nolintNames(text, analyzer string) bool {
+	directive, ok := strings.CutPrefix(strings.TrimSpace(strings.TrimPrefix(text, "//")), "nolint")
+	if !ok {
+		return false
+	}
+
+	names, ok := strings.CutPrefix(directive, ":")
+	if !ok {
+		return directive == "" || strings.HasPrefix(directive, " ")
+	}
+
+	names, _, _ = strings.Cut(names, " ")
+	for name := range strings.SplitSeq(names, ",") {
+		if name == analyzer || name == "oopsvet" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// oopsCallee returns the function (or method, including interface methods) of package oops called by the call
+// expression, if any.
+func oopsCallee(info *types.Info, call *ast.CallExpr) *types.Func {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != PackagePath {
+		return nil
+	}
+
+	return fn
+}
+
+// oopsMethod returns the name of the method of package oops called by the call expression, and its receiver
+// expression, if any.
+func oopsMethod(info *types.Info, call *ast.CallExpr) (string, ast.Expr) {
+	fn := oopsCallee(info, call)
+	if fn == nil || fn.Signature().Recv() == nil {
+		return "", nil
+	}
+
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return "", nil
+	}
+
+	return fn.Name(), sel.X
+}
+
+// isDefined returns true if the type is oops.ErrorDefined, or the type returned by oops.Define.
+func isDefined(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != PackagePath {
+		return false
+	}
+
+	switch named.Obj().Name() {
+	case "ErrorDefined", "errorDefined":
+		return true
+	default:
+		return false
+	}
+}
+
+// exprObject returns the object of an identifier or package-qualified identifier, if any.
+func exprObject(info *types.Info, expr ast.Expr) types.Object {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return info.Uses[e]
+	case *ast.SelectorExpr:
+		if x, ok := ast.Unparen(e.X).(*ast.Ident); ok {
+			if _, ok := info.Uses[x].(*types.PkgName); ok {
+				return info.Uses[e.Sel]
+			}
+		}
+	}
+
+	return nil
+}
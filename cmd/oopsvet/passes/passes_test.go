@@ -0,0 +1,21 @@
+package passes_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"go.sdls.io/oops/cmd/oopsvet/passes"
+)
+
+func TestAnalyzers(t *testing.T) {
+	t.Parallel()
+
+	for _, analyzer := range passes.Analyzers {
+		t.Run(analyzer.Name, func(t *testing.T) {
+			t.Parallel()
+
+			analysistest.Run(t, analysistest.TestData(), analyzer, analyzer.Name)
+		})
+	}
+}
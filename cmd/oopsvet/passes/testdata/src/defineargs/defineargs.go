@@ -0,0 +1,26 @@
+package defineargs
+
+import (
+	"go.sdls.io/oops/pkg/oops"
+)
+
+type code string
+
+var (
+	ErrA     = oops.Define("code", "a")
+	ErrB     = oops.Define("code", code("b"), "status", 400)
+	ErrOdd   = oops.Define("code", "odd", "status") // want `Define called with an odd number of props \(3\), it panics`
+	ErrKey   = oops.Define(1, "key")                // want `Define key 1 must be a string, got int, it panics`
+	ErrChild = ErrA.Child("status")                 // want `Child called with an odd number of props \(1\), it panics`
+)
+
+var Status = oops.Key[int]("status")
+
+var (
+	ErrTyped     = oops.Define(Status.Is(404), "code", "typed")
+	ErrTypedTail = oops.Define("code", "a", Status.Is(404), "b") // want `Define called with an odd number of props \(4\), it panics`
+)
+
+func props() []any { return []any{"code", "spread"} }
+
+var ErrSpread = oops.Define(props()...)
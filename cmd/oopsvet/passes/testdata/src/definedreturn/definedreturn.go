@@ -0,0 +1,34 @@
+package definedreturn
+
+import (
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var (
+	ErrA = oops.Define("code", "a")
+	ErrB = oops.Define("code", "b")
+)
+
+var ErrDefined oops.ErrorDefined = ErrA
+
+func definedReturn(fail bool) error {
+	if fail {
+		return ErrA // want `ErrorDefined ErrA returned as error, its Error method panics; use ErrA.Yeet\(\) or ErrA.Wrap\(err\)`
+	}
+
+	return ErrA.Yeet()
+}
+
+func definedReturnMulti() (int, error) {
+	return 0, ErrDefined // want `ErrorDefined ErrDefined returned as error`
+}
+
+func definedReturnLit() {
+	_ = func() error {
+		return ErrB // want `ErrorDefined ErrB returned as error`
+	}
+
+	_ = func() oops.ErrorDefined {
+		return ErrB
+	}
+}
@@ -0,0 +1,24 @@
+package discardedresult
+
+import (
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var (
+	ErrA = oops.Define("code", "a")
+	ErrB = oops.Define("code", "b")
+)
+
+func discarded(err oops.Error) oops.Error {
+	err.PathSetf("items[%d]", 1) // want `result of PathSetf is discarded, the returned Error must be used`
+	err.Append(ErrA.Yeet())      // want `result of Append is discarded`
+
+	_ = err.PathSetf("items")
+
+	err.Append(ErrA.Yeet()) //nolint:discardedresult // checks the receiver is updated
+	err.Append(ErrA.Yeet()) //nolint:errcheck,oopsvet
+	err.Append(ErrA.Yeet()) //nolint
+	err.Append(ErrA.Yeet()) //nolint:errcheck // want `result of Append is discarded`
+
+	return err.Append(ErrB.Yeet())
+}
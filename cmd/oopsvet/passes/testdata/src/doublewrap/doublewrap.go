@@ -0,0 +1,66 @@
+package doublewrap
+
+import (
+	"errors"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var (
+	ErrA = oops.Define("code", "a")
+	ErrB = oops.Define("code", "b")
+)
+
+func doubleWrap(err error) error {
+	if err == nil {
+		return ErrA.Wrap(ErrA.Wrapf(err, "inner")) // want `error of ErrA wrapped again with ErrA, use Explainf to add an explanation instead`
+	}
+
+	if errors.Is(err, ErrB.Yeet()) {
+		return ErrA.Wrapf(ErrA.Yeet(), "outer") // want `error of ErrA wrapped again with ErrA`
+	}
+
+	return ErrA.Wrap(ErrB.Wrap(err))
+}
+
+func doubleWrapVar(err error, retry bool) error {
+	wrapped := ErrA.Wrapf(err, "inner")
+	if retry {
+		return ErrA.Wrap(wrapped) // want `error of ErrA wrapped again with ErrA, use Explainf to add an explanation instead`
+	}
+
+	var yeeted error = ErrB.Yeet()
+	_ = ErrA.Wrap(yeeted)
+	_ = ErrB.Wrapf(yeeted, "outer") // want `error of ErrB wrapped again with ErrB`
+
+	err = ErrA.Wrap(err)
+	err = ErrA.Wrap(err) // want `error of ErrA wrapped again with ErrA`
+
+	err = errors.New("other")
+	return ErrA.Wrap(err)
+}
+
+func doubleWrapBranches(err error, retry bool) error {
+	if retry {
+		err = ErrA.Wrap(err)
+	}
+
+	switch {
+	case err == nil:
+		err := ErrB.Yeet()
+		return ErrB.Wrap(err) // want `error of ErrB wrapped again with ErrB`
+	case retry:
+		var reset error = ErrB.Yeet()
+		resetError(&reset)
+
+		return ErrB.Wrap(reset)
+	}
+
+	go func() {
+		_ = ErrA.Wrap(err)
+	}()
+
+	return ErrA.Wrap(err)
+}
+
+func resetError(errp *error) {}
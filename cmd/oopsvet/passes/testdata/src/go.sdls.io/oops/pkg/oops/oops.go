@@ -0,0 +1,58 @@
+// Package oops is a stub of go.sdls.io/oops/pkg/oops, with the API checked by the analyzers.
+package oops
+
+type Error interface {
+	error
+	Append(errs ...Error) Error
+	Explainf(format string, args ...any)
+	PathSetf(path string, args ...any) Error
+}
+
+type ErrorDefined interface {
+	error
+	Yeet() Error
+	Yeetf(format string, args ...any) Error
+	Wrap(err error) Error
+	Wrapf(err error, format string, args ...any) Error
+}
+
+type errorDefined struct{}
+
+func Define(props ...any) *errorDefined { return &errorDefined{} }
+
+func Explainf(err error, format string, args ...any) Error { return nil }
+
+func (defined *errorDefined) Child(props ...any) *errorDefined { return defined }
+
+func (defined *errorDefined) Error() string { panic("oops") }
+
+func (defined *errorDefined) Yeet() Error { return nil }
+
+func (defined *errorDefined) Yeetf(format string, args ...any) Error { return nil }
+
+func (defined *errorDefined) Wrap(err error) Error { return nil }
+
+func (defined *errorDefined) Wrapf(err error, format string, args ...any) Error { return nil }
+
+type errorImpl struct{}
+
+func (err *errorImpl) Error() string { return "oops" }
+
+func (err *errorImpl) Append(errs ...Error) Error { return err }
+
+func (err *errorImpl) Explainf(format string, args ...any) {}
+
+func (err *errorImpl) PathSetf(path string, args ...any) Error { return err }
+
+var NilErr = Error((*errorImpl)(nil))
+
+type Prop struct {
+	Key   string
+	Value any
+}
+
+type TypedKey[T any] struct{}
+
+func Key[T any](name string) TypedKey[T] { return TypedKey[T]{} }
+
+func (key TypedKey[T]) Is(value T) Prop { return Prop{} }
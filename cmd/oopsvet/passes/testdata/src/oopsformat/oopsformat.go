@@ -0,0 +1,53 @@
+package oopsformat
+
+import (
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var (
+	ErrA = oops.Define("code", "a")
+	ErrB = oops.Define("code", "b")
+)
+
+func format(err oops.Error, name string) {
+	_ = ErrA.Yeetf("user %s", name)
+	_ = ErrA.Yeetf("user %s %d", name) // want `Yeetf format "user %s %d" reads 2 args, but the call has 1`
+	_ = ErrA.Yeetf("user %s")          // want `Yeetf format "user %s" has verbs but no args, it is used verbatim`
+	_ = ErrA.Yeetf("100% done")
+	_ = ErrA.Yeetf("%*d%%", 4, 2)
+	_ = ErrA.Yeetf("%[1]s %[1]s", name)
+	_ = ErrA.Wrapf(err, "user %w", err)   // want `Wrapf format "user %w" uses %w, which is not supported; wrap errors with Wrap or Wrapf`
+	_ = ErrA.Wrapf(err, "user", name)     // want `Wrapf format "user" reads 0 args, but the call has 1`
+	_ = oops.Explainf(err, "id=%d", 1, 2) // want `Explainf format "id=%d" reads 1 args, but the call has 2`
+	_ = err.PathSetf("items[%d]", 1)
+
+	err.Explainf("%s=%v", name) // want `Explainf format "%s=%v" reads 2 args, but the call has 1`
+}
+
+type user struct {
+	name string
+	id   int
+}
+
+type id int
+
+func (id id) String() string { return "id" }
+
+type node []node
+
+func types(err oops.Error, name string, count int, ptr *user, raw []byte, ids []id, names []string, users [1]*user) {
+	_ = ErrA.Yeetf("%s %d %v %T %q %x", name, count, ptr, ptr, count, raw)
+	_ = ErrA.Yeetf("%s %s %s %d %p", err, raw, ids, ptr, ptr)
+	_ = ErrA.Yeetf("%s %v %x", ids[0], user{}, node{})
+	_ = ErrA.Yeetf("%*d %.*f", count, count, 2, 1.5)
+	_ = err.PathSetf("items[%d]", count)
+
+	_ = ErrA.Yeetf("user %d", name)     // want `Yeetf format %d has arg name of wrong type string`
+	_ = ErrA.Yeetf("%t", count)         // want `Yeetf format %t has arg count of wrong type int`
+	_ = ErrA.Yeetf("%s", ptr)           // want `Yeetf format %s has arg ptr of wrong type \*user`
+	_ = ErrA.Yeetf("%d", names)         // want `Yeetf format %d has arg names of wrong type \[\]string`
+	_ = ErrA.Yeetf("%*d", name, count)  // want `Yeetf format %d uses non-int name of type string as argument of \*`
+	_ = err.PathSetf("items[%d]", name) // want `PathSetf format %d has arg name of wrong type string`
+	_ = oops.Explainf(err, "%f", count) // want `Explainf format %f has arg count of wrong type int`
+	_ = ErrA.Wrapf(err, "%s", users)    // want `Wrapf format %s has arg users of wrong type \[1\]\*user`
+}
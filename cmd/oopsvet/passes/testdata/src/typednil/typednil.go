@@ -0,0 +1,50 @@
+package typednil
+
+import (
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var ErrA = oops.Define("code", "a")
+
+type customError struct{}
+
+func (err *customError) Error() string { return "custom" }
+
+func (err *customError) Append(errs ...oops.Error) oops.Error { return err }
+
+func (err *customError) Explainf(format string, args ...any) {}
+
+func (err *customError) PathSetf(path string, args ...any) oops.Error { return err }
+
+func lookup() *customError { return nil }
+
+func pointer() error {
+	var err *customError
+
+	return err // want `err \(of type \*typednil.customError\) returned as error is a non-nil error even if the pointer is nil; return an oops.Error or use oops.Normalize`
+}
+
+func call() error {
+	return lookup() // want `lookup\(\) \(of type \*typednil.customError\) returned as error`
+}
+
+func nilErr() error {
+	return oops.NilErr // want `oops.NilErr returned as error is a non-nil error, return nil instead`
+}
+
+func fine(fail bool) error {
+	if fail {
+		return &customError{}
+	}
+
+	var err oops.Error = ErrA.Yeet()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func notError() *customError {
+	return lookup()
+}
@@ -0,0 +1,114 @@
+package passes
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// TypedNil reports variables (or other values) of a pointer type implementing oops.Error returned as error, and
+// oops.NilErr returned as error. If the pointer is nil, the returned error is not (being an interface holding a typed
+// nil), such that err != nil checks of the callers succeed.
+var TypedNil = &analysis.Analyzer{
+	Name:     "typednil",
+	Doc:      "report oops.Error pointers returned as error, which are non-nil errors even if the pointer is nil",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runTypedNil,
+}
+
+func runTypedNil(pass *analysis.Pass) (any, error) {
+	iface := oopsErrorInterface(pass.Pkg)
+	if iface == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector) //nolint:forcetypeassert
+	errorType := types.Universe.Lookup("error").Type()
+
+	inspect.WithStack([]ast.Node{(*ast.ReturnStmt)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		sig := enclosingSignature(pass.TypesInfo, stack)
+		if sig == nil {
+			return true
+		}
+
+		ret := n.(*ast.ReturnStmt) //nolint:forcetypeassert
+		if len(ret.Results) != sig.Results().Len() {
+			return true
+		}
+
+		for idx, result := range ret.Results {
+			if !types.Identical(sig.Results().At(idx).Type(), errorType) {
+				continue
+			}
+
+			if obj := exprObject(pass.TypesInfo, result); obj != nil && obj.Pkg() != nil &&
+				obj.Pkg().Path() == PackagePath && obj.Name() == "NilErr" {
+				reportf(pass, result.Pos(), "oops.NilErr returned as error is a non-nil error, return nil instead")
+				continue
+			}
+
+			if !maybeTypedNil(pass.TypesInfo, result, iface) {
+				continue
+			}
+
+			reportf(pass, result.Pos(),
+				"%s (of type %s) returned as error is a non-nil error even if the pointer is nil; "+
+					"return an oops.Error or use oops.Normalize",
+				types.ExprString(result), pass.TypesInfo.TypeOf(result))
+		}
+
+		return true
+	})
+
+	return nil, nil //nolint:nilnil
+}
+
+// maybeTypedNil returns true if the expression is of a pointer type implementing oops.Error, unless it's known to be
+// non-nil (such as &T{}).
+func maybeTypedNil(info *types.Info, expr ast.Expr, iface *types.Interface) bool {
+	t := info.TypeOf(expr)
+	if _, ok := t.(*types.Pointer); !ok || !types.Implements(t, iface) {
+		return false
+	}
+
+	switch ast.Unparen(expr).(type) {
+	case *ast.UnaryExpr, *ast.CompositeLit:
+		return false
+	default:
+		return true
+	}
+}
+
+// oopsErrorInterface returns the oops.Error interface, if the package is (or directly imports) package oops.
+func oopsErrorInterface(pkg *types.Package) *types.Interface {
+	oops := pkg
+	if pkg.Path() != PackagePath {
+		oops = nil
+		for _, imported := range pkg.Imports() {
+			if imported.Path() == PackagePath {
+				oops = imported
+				break
+			}
+		}
+	}
+
+	if oops == nil {
+		return nil
+	}
+
+	obj, ok := oops.Scope().Lookup("Error").(*types.TypeName)
+	if !ok {
+		return nil
+	}
+
+	iface, _ := obj.Type().Underlying().(*types.Interface)
+
+	return iface
+}
@@ -1,48 +1,157 @@
 package unsafe
 
 import (
-	"bytes"
-	"fmt"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 )
 
-var (
-	stackUnknown = []byte("???")
-	stackMidDot  = []byte("·")
-	stackDot     = []byte(".")
-	stackSlash   = []byte("/")
+const (
+	stackUnknown = "???"
+	stackMidDot  = "·"
+	stackDot     = "."
+	stackSlash   = "/"
+
+	stackDepth = 32
 )
 
-// Stack returns stack information in a formatted string slice.
-func Stack(skip int) []string {
-	s := make([]string, 0, 10)
+// Trace holds the program counters of a stack. The program counters are only symbolized and formatted once, the
+// first time Trace.Frames is called.
+type Trace struct {
+	pcs []uintptr
+
+	once   sync.Once
+	frames []string
+}
+
+// Capture records the program counters of the calling goroutine stack, skipping the given number of frames (with 0
+// being Capture itself, as with runtime.Caller).
+func Capture(skip int) *Trace {
+	pcs := make([]uintptr, stackDepth)
+
+	for {
+		n := runtime.Callers(skip+1, pcs)
+		if n < len(pcs) {
+			return &Trace{pcs: pcs[:n]}
+		}
+
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+}
+
+// CapturePanic is the same as Capture, but if called (directly or not) from a deferred function of a panicking
+// goroutine, the frames of the deferred functions and of the runtime panic handling are removed, such that the trace
+// starts at the function that panicked.
+func CapturePanic(skip int) *Trace {
+	t := Capture(skip + 1)
 
-	for idx := skip; ; idx++ {
-		pc, file, line, ok := runtime.Caller(idx)
-		if !ok {
-			break
+	for idx, pc := range t.pcs {
+		if stackFunctionName(pc) != "runtime.gopanic" {
+			continue
 		}
 
-		s = append(s,
-			fmt.Sprintf("%s:%d (0x%x): %s", file, line, pc, stackFunction(pc)))
+		// skip the runtime frames that led to the panic (eg: runtime.sigpanic or runtime.panicIndex)
+		idx++
+		for idx < len(t.pcs) && strings.HasPrefix(stackFunctionName(t.pcs[idx]), "runtime.") {
+			idx++
+		}
+
+		t.pcs = t.pcs[idx:]
+
+		break
+	}
+
+	return t
+}
+
+// Caller returns the program counter of a single frame of the calling goroutine stack, skipping the given number of
+// frames (with 0 being Caller itself, as with runtime.Caller). Returns 0 if there is no such frame.
+func Caller(skip int) uintptr {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+1, pcs[:]) == 0 {
+		return 0
 	}
 
-	return s
+	return pcs[0]
+}
+
+// CallerFrame returns the file, line and function (formatted as in Trace.Frames) of a program counter returned by
+// Caller.
+func CallerFrame(pc uintptr) (file string, line int, function string) {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+
+	return frame.File, frame.Line, stackFunction(frame.Function)
 }
 
-func stackFunction(pc uintptr) []byte {
-	fn := runtime.FuncForPC(pc)
+func stackFunctionName(pc uintptr) string {
+	fn := runtime.FuncForPC(pc - 1)
 	if fn == nil {
+		return ""
+	}
+
+	return fn.Name()
+}
+
+// Frames returns a Trace with the given already formatted frames, such as the ones returned by Trace.Frames.
+func Frames(frames []string) *Trace {
+	t := &Trace{frames: frames}
+	t.once.Do(func() {})
+
+	return t
+}
+
+// Frames returns the stack information in a formatted string slice. The result is cached, it must not be modified.
+func (t *Trace) Frames() []string {
+	if t == nil {
+		return nil
+	}
+
+	t.once.Do(func() {
+		t.frames = make([]string, 0, len(t.pcs))
+
+		frames := runtime.CallersFrames(t.pcs)
+		for {
+			frame, more := frames.Next()
+			if frame.PC != 0 {
+				t.frames = append(t.frames, stackFrame(frame))
+			}
+
+			if !more {
+				break
+			}
+		}
+	})
+
+	return t.frames
+}
+
+func stackFrame(frame runtime.Frame) string {
+	var b strings.Builder
+
+	b.Grow(len(frame.File) + len(frame.Function) + 32)
+	b.WriteString(frame.File)
+	b.WriteString(":")
+	b.WriteString(strconv.Itoa(frame.Line))
+	b.WriteString(" (0x")
+	b.WriteString(strconv.FormatUint(uint64(frame.PC), 16))
+	b.WriteString("): ")
+	b.WriteString(stackFunction(frame.Function))
+
+	return b.String()
+}
+
+func stackFunction(name string) string {
+	if name == "" {
 		return stackUnknown
 	}
-	name := []byte(fn.Name())
 
-	if slash := bytes.LastIndex(name, stackSlash); slash >= 0 {
+	if slash := strings.LastIndex(name, stackSlash); slash >= 0 {
 		name = name[slash+1:]
 	}
-	if dot := bytes.Index(name, stackDot); dot >= 0 {
+	if dot := strings.Index(name, stackDot); dot >= 0 {
 		name = name[dot+1:]
 	}
 
-	return bytes.ReplaceAll(name, stackMidDot, stackDot)
+	return strings.ReplaceAll(name, stackMidDot, stackDot)
 }
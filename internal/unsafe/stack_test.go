@@ -0,0 +1,130 @@
+package unsafe
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// stackEager is the previous implementation of the trace capture, which formatted every frame on capture. It is kept
+// as the baseline of the benchmarks and as the reference format.
+func stackEager(skip int) []string {
+	s := make([]string, 0, 10)
+
+	for idx := skip; ; idx++ {
+		pc, file, line, ok := runtime.Caller(idx)
+		if !ok {
+			break
+		}
+
+		s = append(s,
+			fmt.Sprintf("%s:%d (0x%x): %s", file, line, pc, stackEagerFunction(pc)))
+	}
+
+	return s
+}
+
+func stackEagerFunction(pc uintptr) []byte {
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return []byte(stackUnknown)
+	}
+	name := []byte(fn.Name())
+
+	if slash := bytes.LastIndex(name, []byte(stackSlash)); slash >= 0 {
+		name = name[slash+1:]
+	}
+	if dot := bytes.Index(name, []byte(stackDot)); dot >= 0 {
+		name = name[dot+1:]
+	}
+
+	return bytes.ReplaceAll(name, []byte(stackMidDot), []byte(stackDot))
+}
+
+func TestCaller(t *testing.T) {
+	t.Parallel()
+
+	file, line, function := CallerFrame(Caller(1))
+	_, wantFile, wantLine, _ := runtime.Caller(0)
+
+	if file != wantFile || line != wantLine-1 || function != "TestCaller" {
+		t.Fatalf("unexpected frame: %s:%d %s", file, line, function)
+	}
+
+	if Caller(1000) != 0 {
+		t.Fatal("expected no frame beyond the stack")
+	}
+}
+
+func TestCapture(t *testing.T) {
+	t.Parallel()
+
+	eager, lazy := stackEager(1), Capture(1).Frames()
+
+	if len(eager) != len(lazy) {
+		t.Fatalf("expected %d frames, got %d", len(eager), len(lazy))
+	}
+
+	if !strings.Contains(lazy[0], "stack_test.go") || !strings.HasSuffix(lazy[0], ": TestCapture") {
+		t.Fatalf("unexpected first frame: %s", lazy[0])
+	}
+
+	// the first frame differs by the program counter, as the calls are not the same
+	for idx := 1; idx < len(eager); idx++ {
+		if eager[idx] != lazy[idx] {
+			t.Fatalf("frame %d mismatch\n got: %s\nwant: %s", idx, lazy[idx], eager[idx])
+		}
+	}
+}
+
+func TestTrace_Frames(t *testing.T) {
+	t.Parallel()
+
+	trace := Capture(0)
+	frames := trace.Frames()
+
+	if !strings.HasSuffix(frames[0], ": Capture") {
+		t.Fatalf("expected first frame to be Capture, got %s", frames[0])
+	}
+
+	if again := trace.Frames(); &again[0] != &frames[0] {
+		t.Fatal("expected frames to be cached")
+	}
+
+	if (*Trace)(nil).Frames() != nil {
+		t.Fatal("expected nil trace to have no frames")
+	}
+
+	formatted := Frames([]string{"a", "b"}).Frames()
+	if len(formatted) != 2 || formatted[1] != "b" {
+		t.Fatalf("unexpected formatted frames: %v", formatted)
+	}
+}
+
+func BenchmarkStack(b *testing.B) {
+	b.Run("eager", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for iter := 0; iter < b.N; iter++ {
+			_ = stackEager(1)
+		}
+	})
+
+	b.Run("capture", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for iter := 0; iter < b.N; iter++ {
+			_ = Capture(1)
+		}
+	})
+
+	b.Run("capture and frames", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for iter := 0; iter < b.N; iter++ {
+			_ = Capture(1).Frames()
+		}
+	})
+}
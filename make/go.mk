@@ -25,6 +25,9 @@ SOURCE_FILES?=$$(find . -name '*.go' | grep -v pb.go | grep -v vendor)
 DIR_OUT   := out
 FILE_COV  := $(DIR_OUT)/cover.out
 
+# NESTED MODULES
+DIR_OOPSVET := cmd/oopsvet
+
 # MOD
 ifneq ("$(wildcard go.mod/)","") # check go.mod exists
 PROJECT_MOD_NAME := $(shell go list -m -mod=readonly)
@@ -107,6 +110,16 @@ test: $(DIR_OUT) ## run unit tests
 		-timeout=30s -parallel=20 -failfast \
 		-covermode=atomic -coverpkg=./... -coverprofile=$(FILE_COV).txt \
 		./...
+	@printf "$(FMT_PRFX) running $(FMT_INFO)$(DIR_OOPSVET)$(FMT_END) tests\n"
+	@cd $(DIR_OOPSVET) && gotestsum \
+		--junitfile $(abspath $(FILE_COV))_oopsvet.xml \
+		--format short -- \
+		-race \
+		-timeout=30s -parallel=20 -failfast \
+		./...
+	@printf "$(FMT_PRFX) running $(FMT_INFO)oopsvet$(FMT_END) on the module\n"
+	@cd $(DIR_OOPSVET) && $(GO) build -o $(abspath $(DIR_OUT))/oopsvet .
+	@$(GO) vet -vettool=$(abspath $(DIR_OUT))/oopsvet ./...
 
 .PHONY: test-deps
 test-deps: ## run tests with dependencies
@@ -0,0 +1,187 @@
+package oops
+
+import (
+	"container/list"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Aggregator counts the errors added to it, grouped by their Fingerprint. It keeps at most a given number of groups,
+// evicting the least recently seen ones, such that its memory is bounded regardless of the errors added. An Aggregator
+// is safe for concurrent use.
+type Aggregator struct {
+	limit   int
+	options []FingerprintOption
+
+	mu      sync.Mutex
+	since   time.Time
+	groups  map[string]*list.Element
+	recent  *list.List
+	defined map[ErrorDefined]int
+	evicted int
+}
+
+// AggregateEntry is the group of the errors added to an Aggregator with the same Fingerprint.
+type AggregateEntry struct {
+	Fingerprint string
+	Count       int
+	FirstSeen   time.Time
+	LastSeen    time.Time
+
+	// Sample is the first error added to the group.
+	Sample error
+}
+
+// AggregateDefined is the number of errors added to an Aggregator from a definition (see Error.Source).
+type AggregateDefined struct {
+	Defined ErrorDefined
+	Count   int
+}
+
+// AggregateSnapshot is the state of an Aggregator, as returned by Aggregator.Snapshot.
+type AggregateSnapshot struct {
+	// Since is the time the Aggregator was created, or last reset.
+	Since time.Time
+
+	// Entries holds the groups, the most counted first.
+	Entries []AggregateEntry
+
+	// Defined holds the counts by definition, the most counted first.
+	Defined []AggregateDefined
+
+	// Evicted is the number of groups evicted to keep at most the limit of groups.
+	Evicted int
+}
+
+// NewAggregator returns an Aggregator keeping at most limit groups (and as many definition counts), using the given
+// options to fingerprint the errors. Panics if limit is not positive.
+func NewAggregator(limit int, options ...FingerprintOption) *Aggregator {
+	if limit <= 0 {
+		panic("oops: NewAggregator requires a positive limit")
+	}
+
+	aggregator := &Aggregator{
+		limit:   limit,
+		options: options,
+	}
+	aggregator.reset()
+
+	return aggregator
+}
+
+// Add adds the error to its group, returning the number of errors of the group (including this one), such that the
+// first occurrence of each error can be told apart (eg: to only log it once). Nil errors (see Normalize) are ignored,
+// returning 0.
+func (aggregator *Aggregator) Add(err error) int {
+	err = Normalize(err)
+	if err == nil {
+		return 0
+	}
+
+	fingerprint := Fingerprint(err, aggregator.options...)
+	now := time.Now()
+
+	aggregator.mu.Lock()
+	defer aggregator.mu.Unlock()
+
+	if v, ok := err.(Error); ok && v.Source() != nil { //nolint:errorlint
+		if _, counted := aggregator.defined[v.Source()]; counted || len(aggregator.defined) < aggregator.limit {
+			aggregator.defined[v.Source()]++
+		}
+	}
+
+	if element, ok := aggregator.groups[fingerprint]; ok {
+		entry := element.Value.(*AggregateEntry) //nolint:forcetypeassert
+		entry.Count++
+		entry.LastSeen = now
+		aggregator.recent.MoveToFront(element)
+
+		return entry.Count
+	}
+
+	if aggregator.recent.Len() == aggregator.limit {
+		oldest := aggregator.recent.Back()
+		aggregator.recent.Remove(oldest)
+		delete(aggregator.groups, oldest.Value.(*AggregateEntry).Fingerprint) //nolint:forcetypeassert
+		aggregator.evicted++
+	}
+
+	aggregator.groups[fingerprint] = aggregator.recent.PushFront(&AggregateEntry{
+		Fingerprint: fingerprint,
+		Count:       1,
+		FirstSeen:   now,
+		LastSeen:    now,
+		Sample:      err,
+	})
+
+	return 1
+}
+
+// Snapshot returns the current state of the Aggregator.
+func (aggregator *Aggregator) Snapshot() AggregateSnapshot {
+	aggregator.mu.Lock()
+	defer aggregator.mu.Unlock()
+
+	return aggregator.snapshot()
+}
+
+// Reset removes every group and count from the Aggregator.
+func (aggregator *Aggregator) Reset() {
+	aggregator.mu.Lock()
+	defer aggregator.mu.Unlock()
+
+	aggregator.reset()
+}
+
+// Flush returns the current state of the Aggregator and resets it, such that no error added concurrently is lost
+// between the two (eg: to report the errors of the last period).
+func (aggregator *Aggregator) Flush() AggregateSnapshot {
+	aggregator.mu.Lock()
+	defer aggregator.mu.Unlock()
+
+	snapshot := aggregator.snapshot()
+	aggregator.reset()
+
+	return snapshot
+}
+
+func (aggregator *Aggregator) snapshot() AggregateSnapshot {
+	snapshot := AggregateSnapshot{
+		Since:   aggregator.since,
+		Entries: make([]AggregateEntry, 0, aggregator.recent.Len()),
+		Defined: make([]AggregateDefined, 0, len(aggregator.defined)),
+		Evicted: aggregator.evicted,
+	}
+
+	for element := aggregator.recent.Front(); element != nil; element = element.Next() {
+		snapshot.Entries = append(snapshot.Entries, *element.Value.(*AggregateEntry)) //nolint:forcetypeassert
+	}
+
+	for defined, count := range aggregator.defined {
+		snapshot.Defined = append(snapshot.Defined, AggregateDefined{Defined: defined, Count: count})
+	}
+
+	// the entries are sorted by recency, keep it for equal counts
+	sort.SliceStable(snapshot.Entries, func(i, j int) bool {
+		return snapshot.Entries[i].Count > snapshot.Entries[j].Count
+	})
+
+	sort.Slice(snapshot.Defined, func(i, j int) bool {
+		if snapshot.Defined[i].Count != snapshot.Defined[j].Count {
+			return snapshot.Defined[i].Count > snapshot.Defined[j].Count
+		}
+
+		return goSyntaxDefined(snapshot.Defined[i].Defined) < goSyntaxDefined(snapshot.Defined[j].Defined)
+	})
+
+	return snapshot
+}
+
+func (aggregator *Aggregator) reset() {
+	aggregator.since = time.Now()
+	aggregator.groups = make(map[string]*list.Element)
+	aggregator.recent = list.New()
+	aggregator.defined = make(map[ErrorDefined]int)
+	aggregator.evicted = 0
+}
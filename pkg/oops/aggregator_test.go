@@ -0,0 +1,131 @@
+package oops_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var (
+	errTestAggregateNotFound = oops.Define("code", "test.aggregate.not_found")
+	errTestAggregateDenied   = oops.Define("code", "test.aggregate.denied")
+)
+
+func TestAggregator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("counts", func(t *testing.T) {
+		t.Parallel()
+
+		aggregator := oops.NewAggregator(10)
+
+		first := errTestAggregateNotFound.Yeetf("user %d not found", 1)
+		if count := aggregator.Add(first); count != 1 {
+			t.Fatalf("expected the first occurrence, got %d", count)
+		}
+
+		if count := aggregator.Add(errTestAggregateNotFound.Yeetf("user %d not found", 2)); count != 2 {
+			t.Fatalf("expected the second occurrence, got %d", count)
+		}
+
+		aggregator.Add(errTestAggregateDenied.Yeet())
+		aggregator.Add(errTestAggregateNotFound.Yeetf("user %d gone", 3))
+		aggregator.Add(errors.New("foreign"))
+
+		if count := aggregator.Add(oops.NilErr); count != 0 {
+			t.Fatalf("expected nil errors to be ignored, got %d", count)
+		}
+
+		snapshot := aggregator.Snapshot()
+		if len(snapshot.Entries) != 4 {
+			t.Fatalf("expected 4 entries, got %d", len(snapshot.Entries))
+		}
+
+		top := snapshot.Entries[0]
+		if top.Count != 2 || top.Sample != first || top.Fingerprint != oops.Fingerprint(first) {
+			t.Fatalf("unexpected top entry %+v", top)
+		}
+
+		if top.LastSeen.Before(top.FirstSeen) || top.FirstSeen.Before(snapshot.Since) {
+			t.Fatalf("unexpected times %+v since %s", top, snapshot.Since)
+		}
+
+		if len(snapshot.Defined) != 2 || snapshot.Defined[0].Defined != errTestAggregateNotFound ||
+			snapshot.Defined[0].Count != 3 || snapshot.Defined[1].Count != 1 {
+			t.Fatalf("unexpected definitions %+v", snapshot.Defined)
+		}
+	})
+
+	t.Run("eviction", func(t *testing.T) {
+		t.Parallel()
+
+		aggregator := oops.NewAggregator(2)
+		aggregator.Add(errTestAggregateNotFound.Yeetf("a"))
+		aggregator.Add(errTestAggregateNotFound.Yeetf("b"))
+		aggregator.Add(errTestAggregateNotFound.Yeetf("a"))
+		aggregator.Add(errTestAggregateNotFound.Yeetf("c"))
+
+		snapshot := aggregator.Snapshot()
+		if snapshot.Evicted != 1 || len(snapshot.Entries) != 2 {
+			t.Fatalf("expected 1 eviction and 2 entries, got %d and %d", snapshot.Evicted, len(snapshot.Entries))
+		}
+
+		for _, entry := range snapshot.Entries {
+			if entry.Sample.Error() == "b" {
+				t.Fatal("expected the least recently seen entry to be evicted")
+			}
+		}
+	})
+
+	t.Run("flush", func(t *testing.T) {
+		t.Parallel()
+
+		aggregator := oops.NewAggregator(10)
+		aggregator.Add(errTestAggregateDenied.Yeet())
+
+		if snapshot := aggregator.Flush(); len(snapshot.Entries) != 1 {
+			t.Fatalf("expected 1 entry, got %d", len(snapshot.Entries))
+		}
+
+		if snapshot := aggregator.Snapshot(); len(snapshot.Entries) != 0 || len(snapshot.Defined) != 0 {
+			t.Fatalf("expected the flush to reset, got %+v", snapshot)
+		}
+
+		aggregator.Add(errTestAggregateDenied.Yeet())
+		aggregator.Reset()
+
+		if snapshot := aggregator.Snapshot(); len(snapshot.Entries) != 0 {
+			t.Fatalf("expected the reset to remove the entries, got %d", len(snapshot.Entries))
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+
+		aggregator := oops.NewAggregator(10)
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Go(func() {
+				for range 100 {
+					aggregator.Add(errTestAggregateDenied.Yeet())
+				}
+			})
+		}
+		wg.Wait()
+
+		if snapshot := aggregator.Snapshot(); snapshot.Entries[0].Count != 800 {
+			t.Fatalf("expected 800 errors, got %d", snapshot.Entries[0].Count)
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, "requires a positive limit", func() {
+			oops.NewAggregator(0)
+		})
+	})
+}
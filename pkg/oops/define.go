@@ -1,5 +1,14 @@
 package oops
 
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
 func defaultFormatter(err Error) string {
 	if err == nil {
 		return "oops.Error(nil)"
@@ -13,22 +22,135 @@ func defaultFormatter(err Error) string {
 	return explanation
 }
 
+// Define creates a new ErrorDefined with the given props, given as key-value pairs with string keys, or as Prop values
+// of typed keys (see Key). The definition is recorded in DefaultRegistry, which retains it: Define is meant for package
+// level definitions, the definitions created at runtime must be removed with Registry.Unregister to be garbage
+// collected.
 func Define(props ...any) *errorDefined {
 	defined := &errorDefined{
+		site:      definedSite(2),
 		formatter: defaultFormatter,
 	}
 
-	if len(props) > 0 {
-		if len(props)%2 != 0 {
+	defined.props = defineProps(defined.props, props)
+	DefaultRegistry.Register(defined)
+
+	return defined
+}
+
+// DefinedID returns a stable identity of the definition, being the value of its "code" prop (the key of
+// DefaultRegistry, unless inherited from the parent of a Child), which survives the changes of the source files.
+// Definitions without their own code are identified by the location of the Define (or Child) call creating them, as
+// the package path, the file name and the line (such as "example.com/app/errors.go:12"), suffixed with "#2", "#3" and
+// so on for the definitions created at the same line (such as by a helper wrapping Define), in creation order. Returns
+// the Go syntax of the definitions created otherwise (see ErrTODO and ErrUncaught).
+func DefinedID(defined ErrorDefined) string {
+	vd, ok := defined.(*errorDefined)
+	if !ok || vd == nil {
+		return goSyntaxDefined(defined)
+	}
+
+	if code, ok := vd.code(); ok {
+		return code
+	}
+
+	if vd.site != "" {
+		return vd.site
+	}
+
+	return goSyntaxDefined(defined)
+}
+
+// code returns the value of the key prop of DefaultRegistry of the definition, unless inherited from its parent.
+func (defined *errorDefined) code() (string, bool) {
+	key := DefaultRegistry.Key()
+
+	definedMu.RLock()
+	defer definedMu.RUnlock()
+
+	value, ok := defined.props[key]
+	if !ok {
+		return "", false
+	}
+
+	code := fmt.Sprint(value)
+	if defined.parent != nil {
+		if inherited, ok := defined.parent.props[key]; ok && fmt.Sprint(inherited) == code {
+			return "", false
+		}
+	}
+
+	return code, true
+}
+
+// defineProps adds the given key-value pairs (or Prop values, see Key) to the given props, returning the (possibly
+// new) props. Panics if a key is not a string or if a value does not match the type of its Key.
+func defineProps(to map[string]any, props []any) map[string]any {
+	if len(props) == 0 {
+		return to
+	}
+
+	if to == nil {
+		to = make(map[string]any, len(props)/2)
+	}
+
+	for idx := 0; idx < len(props); idx++ {
+		if prop, ok := props[idx].(Prop); ok {
+			checkKey(prop.Key, prop.Value)
+			to[prop.Key] = prop.Value
+
+			continue
+		}
+
+		key, ok := props[idx].(string)
+		if !ok {
+			panic(fmt.Sprintf("oops: Define requires string keys (or Prop values), got %T at argument %d", props[idx], idx))
+		}
+
+		if idx+1 == len(props) {
 			panic("oops: Define requires an even number of arguments")
 		}
 
-		defined.props = make(map[string]any, len(props)/2)
+		idx++
+		checkKey(key, props[idx])
+		to[key] = props[idx]
 	}
 
-	for idx := 0; idx < len(props); idx += 2 {
-		defined.props[props[idx].(string)] = props[idx+1]
+	return to
+}
+
+var (
+	definedSitesMu sync.Mutex
+	definedSites   = make(map[string]int)
+)
+
+// definedSite returns the location of the caller of the function calling definedSite (with the given skip, 0 being
+// definedSite itself), as the package path, the file name and the line. The definitions created at the same line (such
+// as in a loop) are told apart by their order, suffixed as "#2", "#3" and so on.
+func definedSite(skip int) string {
+	pc, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
 	}
 
-	return defined
+	pkg := ""
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		pkg = fn.Name()
+		slash := strings.LastIndex(pkg, "/") + 1
+		if dot := strings.Index(pkg[slash:], "."); dot >= 0 {
+			pkg = pkg[:slash+dot]
+		}
+	}
+
+	site := pkg + "/" + filepath.Base(file) + ":" + strconv.Itoa(line)
+
+	definedSitesMu.Lock()
+	defer definedSitesMu.Unlock()
+
+	definedSites[site]++
+	if n := definedSites[site]; n > 1 {
+		site += "#" + strconv.Itoa(n)
+	}
+
+	return site
 }
@@ -1,11 +1,29 @@
 package oops
 
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// definedMu guards the props of all definitions against the Set builder, such that a Registry can safely read them.
+var definedMu sync.RWMutex
+
+// Set sets the prop on the definition. The props are copied on write, as they are shared with (and never modified
+// for) the errors already created from the definition. Panics if the value does not match the type of the Key.
 func (defined *errorDefined) Set(key string, value any) *errorDefined {
-	if defined.props == nil {
-		defined.props = make(map[string]any, 4)
-	}
+	checkKey(key, value)
+
+	definedMu.Lock()
+	defer definedMu.Unlock()
+
+	props := make(map[string]any, len(defined.props)+1)
+	maps.Copy(props, defined.props)
+	props[key] = value
+
+	defined.props = props
+	definedGeneration.Add(1)
 
-	defined.props[key] = value
 	return defined
 }
 
@@ -14,7 +32,56 @@ func (defined *errorDefined) Trace() *errorDefined {
 	return defined
 }
 
+// Snapshot makes the errors of this definition format their explanations (see Error.Explainf) immediately, instead of
+// when first needed. Use it if the explanation args may be modified after the error is created (such as pointers,
+// slices or maps updated later), as the explanation would otherwise show the modified values.
+func (defined *errorDefined) Snapshot() *errorDefined {
+	defined.snapshot = true
+	return defined
+}
+
+// UnwrapNested makes the errors of this definition expose their Error.Nested errors (alongside their parent) through
+// Error.Unwrap, as an error implementing Unwrap() []error, such that errors.Is and errors.As also check the nested
+// errors. Use SetUnwrapNested to enable it for all definitions. Errors appended to themselves (see Error.Append) are
+// not exposed again, but longer cycles (such as two errors appended to each other) are then unwrapped endlessly by
+// errors.Is and errors.As, unlike Walk, All and As which visit each Error once.
+func (defined *errorDefined) UnwrapNested() *errorDefined {
+	defined.unwrapNested = true
+	return defined
+}
+
 func (defined *errorDefined) Formatter(formatter Formatter) *errorDefined {
 	defined.formatter = formatter
 	return defined
 }
+
+// Child creates a new ErrorDefined descending from this definition. The child inherits (a copy of) the props, the
+// formatter, the hooks, the tracing, the snapshotting and the unwrapping of this definition, with the given props (as
+// given to Define) added on top. Errors yeeted from the child (or from any of its descendants) match this definition
+// when checked with errors.Is, As, NestedAs or NestedIs. The child is recorded in DefaultRegistry, which retains it (as
+// with Define, see Registry.Unregister).
+func (defined *errorDefined) Child(props ...any) *errorDefined {
+	child := &errorDefined{
+		parent:       defined,
+		site:         definedSite(2),
+		traced:       defined.traced,
+		snapshot:     defined.snapshot,
+		unwrapNested: defined.unwrapNested,
+		formatter:    defined.formatter,
+		hooks:        slices.Clone(defined.hooks),
+	}
+
+	definedMu.RLock()
+	if len(defined.props) != 0 {
+		child.props = make(map[string]any, len(defined.props)+len(props)/2)
+		for k, v := range defined.props {
+			child.props[k] = v
+		}
+	}
+	definedMu.RUnlock()
+
+	child.props = defineProps(child.props, props)
+	DefaultRegistry.Register(child)
+
+	return child
+}
@@ -1,6 +1,8 @@
 package oops_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"go.sdls.io/oops/pkg/oops"
@@ -20,3 +22,75 @@ func TestErrorDefined_Set(t *testing.T) {
 		t.Fatalf("foo was not %v, got %v", `"bar"`, v.(string))
 	}
 }
+
+func TestErrorDefined_Child(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errAuth        = oops.Define("type", "auth", "status", 401).Trace()
+		errAuthExpired = errAuth.Child("code", "auth_expired")
+		errAuthRevoked = errAuthExpired.Child("code", "auth_revoked", "status", 403)
+		errOther       = oops.Define("type", "auth", "status", 401)
+	)
+
+	err := errAuthRevoked.Yeetf("revoked at %d", 42)
+
+	if status, _ := err.Get("status"); status != 403 {
+		t.Fatalf("expected child props to override parent props, got %v", status)
+	}
+
+	if typ, _ := err.Get("type"); typ != "auth" {
+		t.Fatalf("expected child to inherit parent props, got %v", typ)
+	}
+
+	if err.Trace() == nil {
+		t.Fatal("expected child to inherit tracing")
+	}
+
+	for _, target := range []oops.ErrorDefined{errAuthRevoked, errAuthExpired, errAuth} {
+		if !errors.Is(err, target) {
+			t.Fatal("expected errors.Is to match the definition and its ancestors")
+		}
+
+		if !target.Is(err) {
+			t.Fatal("expected ErrorDefined.Is to match descendant errors")
+		}
+
+		if _, ok := oops.As(fmt.Errorf("wrap: %w", err), target); !ok {
+			t.Fatal("expected oops.As to match the definition and its ancestors")
+		}
+
+		if !oops.NestedIs(oops.Nest(errOther, err), target) {
+			t.Fatal("expected oops.NestedIs to match the definition and its ancestors")
+		}
+	}
+
+	if errors.Is(err, errOther) || errors.Is(errAuth.Yeet(), errAuthExpired) {
+		t.Fatal("expected errors.Is not to match unrelated or descendant definitions")
+	}
+
+	if errors.Is(err, errAuthExpired.Yeet()) {
+		t.Fatal("expected errors.Is to compare Error sources exactly")
+	}
+
+	registered := false
+	for _, defined := range oops.DefaultRegistry.All() {
+		registered = registered || defined == errAuthRevoked
+	}
+
+	if !registered {
+		t.Fatal("expected child to be recorded in the default registry")
+	}
+}
+
+func TestErrorDefined_Child_formatter(t *testing.T) {
+	t.Parallel()
+
+	parent := oops.Define().Formatter(func(err oops.Error) string {
+		return "parent: " + err.Explanation()
+	})
+
+	if got := parent.Child().Yeetf("child").Error(); got != "parent: child" {
+		t.Fatalf("expected child to inherit the parent formatter, got %q", got)
+	}
+}
@@ -0,0 +1,173 @@
+package oops
+
+import (
+	"sort"
+	"sync"
+)
+
+// CollectOrder is the order of the Error.Nested errors of an Error returned by a ErrorCollectorFinish of
+// errorDefined.CollectSync.
+type CollectOrder int
+
+const (
+	// CollectOrderInsertion keeps the nested errors in the order they were added.
+	CollectOrderInsertion CollectOrder = iota
+
+	// CollectOrderPath sorts the nested errors by their Error.Path (lexically), keeping the insertion order of equal
+	// paths.
+	CollectOrderPath
+)
+
+// CollectSync is the same as Collect, but the returned functions are safe for concurrent use, such as adding errors
+// from multiple goroutines. The nested errors are ordered as specified by the given CollectOrder.
+func (defined *errorDefined) CollectSync(order CollectOrder) (ErrorCollectorFinish, ErrorCollectorAdd) {
+	var (
+		mu   sync.Mutex
+		errs = make([]Error, 0, 4)
+	)
+
+	finish := func() Error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(errs) == 0 {
+			return nil
+		}
+
+		nested := make([]Error, len(errs))
+		copy(nested, errs)
+
+		if order == CollectOrderPath {
+			sort.SliceStable(nested, func(i, j int) bool {
+				return nested[i].Path() < nested[j].Path()
+			})
+		}
+
+		err := defined.newError(OnCollect, nil)
+		err.nested = nested
+
+		return err
+	}
+
+	addf := func(err error, path string, args ...any) {
+		v := collectable(err)
+		if v == nil {
+			return
+		}
+
+		v = v.PathSetf(path, args...)
+
+		mu.Lock()
+		errs = append(errs, v)
+		mu.Unlock()
+	}
+
+	return finish, addf
+}
+
+// CollectIndexed is the same as CollectSync, but each error is added along with an index (such as the index of the
+// item being processed by a goroutine), which is used to order the nested errors. Errors with equal indexes are kept
+// in the order they were added.
+func (defined *errorDefined) CollectIndexed() (ErrorCollectorFinish, ErrorCollectorAddIndexed) {
+	type indexed struct {
+		idx int
+		err Error
+	}
+
+	var (
+		mu   sync.Mutex
+		errs = make([]indexed, 0, 4)
+	)
+
+	finish := func() Error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(errs) == 0 {
+			return nil
+		}
+
+		sorted := make([]indexed, len(errs))
+		copy(sorted, errs)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].idx < sorted[j].idx
+		})
+
+		err := defined.newError(OnCollect, nil)
+		err.nested = make([]Error, len(sorted))
+		for i, e := range sorted {
+			err.nested[i] = e.err
+		}
+
+		return err
+	}
+
+	addf := func(idx int, err error, path string, args ...any) {
+		v := collectable(err)
+		if v == nil {
+			return
+		}
+
+		v = v.PathSetf(path, args...)
+
+		mu.Lock()
+		errs = append(errs, indexed{idx: idx, err: v})
+		mu.Unlock()
+	}
+
+	return finish, addf
+}
+
+// Go calls fn for each index in [0, n), each in its own goroutine, and waits for all of them to return. The returned
+// errors are collected (as with Collect) in the order of their index, with the path formatted using the index as the
+// only argument (eg: "items[%d]"). Unlike Collect, errors that are neither an Error nor an ErrorDefined are wrapped
+// with ErrUncaught instead of panicking, and panics of fn are recovered as ErrUncaught errors (see Recover). Returns
+// nil if all the calls returned nil, or if n is not positive.
+func (defined *errorDefined) Go(n int, path string, fn func(idx int) error) Error { //nolint:ireturn
+	if n <= 0 {
+		return nil
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, n)
+	)
+
+	for idx := 0; idx < n; idx++ {
+		wg.Go(func() {
+			defer Recover(&errs[idx], nil)
+
+			errs[idx] = fn(idx)
+		})
+	}
+
+	wg.Wait()
+
+	var nested []Error
+	for idx, err := range errs {
+		var v Error
+		switch err.(type) { //nolint:errorlint
+		case nil:
+			continue
+		case Error, ErrorDefined:
+			v = collectable(err)
+		default:
+			v = ErrUncaught.Wrap(err)
+		}
+
+		if v == nil {
+			continue
+		}
+
+		nested = append(nested, v.PathSetf(path, idx))
+	}
+
+	if len(nested) == 0 {
+		return nil
+	}
+
+	err := defined.newError(OnCollect, nil)
+	err.nested = nested
+
+	return err
+}
@@ -0,0 +1,203 @@
+package oops_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+func TestErrorDefined_CollectSync(t *testing.T) {
+	t.Parallel()
+
+	errItem := oops.Define("code", "test.collect_sync_item")
+
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+
+		finish, addf := errTest.CollectSync(oops.CollectOrderInsertion)
+
+		var wg sync.WaitGroup
+		for idx := 0; idx < 100; idx++ {
+			wg.Go(func() {
+				if idx%2 == 0 {
+					addf(errItem.Yeet(), "items[%d]", idx)
+				} else {
+					addf(nil, "items[%d]", idx)
+				}
+			})
+		}
+		wg.Wait()
+
+		err := finish()
+		if err == nil || err.Source() != errTest {
+			t.Fatal("expected collected error")
+		}
+
+		if len(err.Nested()) != 50 {
+			t.Fatalf("expected 50 nested errors, got %d", len(err.Nested()))
+		}
+	})
+
+	t.Run("insertion", func(t *testing.T) {
+		t.Parallel()
+
+		finish, addf := errTest.CollectSync(oops.CollectOrderInsertion)
+		addf(errItem.Yeet(), "b")
+		addf(errItem.Yeet(), "a")
+		addf(errItem, "c")
+
+		assertPaths(t, finish(), "b", "a", "c")
+	})
+
+	t.Run("path", func(t *testing.T) {
+		t.Parallel()
+
+		finish, addf := errTest.CollectSync(oops.CollectOrderPath)
+		addf(errItem.Yeetf("first"), "b")
+		addf(errItem.Yeet(), "c")
+		addf(errItem.Yeet(), "a")
+		addf(errItem.Yeetf("second"), "b")
+
+		err := finish()
+		assertPaths(t, err, "a", "b", "b", "c")
+
+		if err.Nested()[1].Explanation() != "first" {
+			t.Fatal("expected equal paths to keep the insertion order")
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+
+		finish, addf := errTest.CollectSync(oops.CollectOrderPath)
+		addf(nil, "a")
+
+		if finish() != nil {
+			t.Fatal("expected nil error")
+		}
+	})
+}
+
+func TestErrorDefined_CollectIndexed(t *testing.T) {
+	t.Parallel()
+
+	errItem := oops.Define("code", "test.collect_indexed_item")
+
+	finish, addf := errTest.CollectIndexed()
+
+	var wg sync.WaitGroup
+	for idx := 9; idx >= 0; idx-- {
+		wg.Go(func() {
+			addf(idx, errItem.Yeet(), "items[%d]", idx)
+		})
+	}
+	wg.Wait()
+
+	assertPaths(t, finish(),
+		"items[0]", "items[1]", "items[2]", "items[3]", "items[4]",
+		"items[5]", "items[6]", "items[7]", "items[8]", "items[9]")
+
+	finish, _ = errTest.CollectIndexed()
+	if finish() != nil {
+		t.Fatal("expected nil error")
+	}
+}
+
+func TestErrorDefined_Go(t *testing.T) {
+	t.Parallel()
+
+	errItem := oops.Define("code", "test.go_item")
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTest.Go(5, "items[%d]", func(idx int) error {
+			switch idx {
+			case 1:
+				return errItem.Yeet()
+			case 3:
+				return errors.New("plain")
+			default:
+				return nil
+			}
+		})
+
+		assertPaths(t, err, "items[1]", "items[3]")
+
+		if err.Nested()[1].Source() != oops.ErrUncaught {
+			t.Fatal("expected plain errors to be wrapped with ErrUncaught")
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+
+		if err := errTest.Go(5, "items[%d]", func(int) error { return nil }); err != nil {
+			t.Fatal("expected nil error")
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTest.Go(3, "items[%d]", func(idx int) error {
+			if idx == 2 {
+				panic("boom")
+			}
+
+			return nil
+		})
+
+		assertPaths(t, err, "items[2]")
+
+		if panicked, _ := err.Nested()[0].Get("panic"); err.Nested()[0].Source() != oops.ErrUncaught || panicked != "boom" {
+			t.Fatalf("expected the panic to be recovered as ErrUncaught, got %v", err.Nested()[0])
+		}
+	})
+
+	t.Run("typed nil", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTest.Go(3, "items[%d]", func(idx int) error {
+			if idx == 1 {
+				return oops.NilErr //nolint:oopsvet // checks NilErr is skipped
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("expected NilErr not to be collected, got %v", err)
+		}
+	})
+
+	t.Run("no calls", func(t *testing.T) {
+		t.Parallel()
+
+		for _, n := range []int{0, -1} {
+			if err := errTest.Go(n, "items[%d]", func(int) error { panic("called") }); err != nil {
+				t.Fatalf("expected nil error for %d calls", n)
+			}
+		}
+	})
+}
+
+func assertPaths(t *testing.T, err oops.Error, paths ...string) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatal("expected collected error")
+	}
+
+	nested := err.Nested()
+	if len(nested) != len(paths) {
+		t.Fatalf("expected %d nested errors, got %d", len(paths), len(nested))
+	}
+
+	for idx, path := range paths {
+		if nested[idx].Path() != path {
+			t.Fatalf("nested[%d] path is %q, want %q", idx, nested[idx].Path(), path)
+		}
+	}
+}
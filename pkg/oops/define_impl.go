@@ -10,54 +10,81 @@ var _ ErrorDefined = &errorDefined{}
 
 //nolint:errname
 type errorDefined struct {
-	traced    bool
-	props     map[string]any
-	formatter Formatter
+	parent       *errorDefined
+	site         string
+	traced       bool
+	snapshot     bool
+	unwrapNested bool
+	props        map[string]any
+	formatter    Formatter
+	hooks        []Hook
 }
 
-func (defined *errorDefined) newError(parent error) *errorImpl {
-	e := &errorImpl{
-		source:      defined,
-		parent:      parent,
-		explanation: strings.Builder{},
-	}
+// newError creates an Error of the definition, capturing the trace (if traced) from the caller of its caller, and
+// calls the hooks (see Hook) with the given event.
+func (defined *errorDefined) newError(event HookEvent, parent error) *errorImpl {
+	e := defined.newErrorUntraced(parent)
 
-	if len(defined.props) != 0 {
-		e.props = make(map[string]any, len(defined.props))
-		for k, v := range defined.props {
-			e.props[k] = v
-		}
+	if defined.traced {
+		e.trace = unsafe.Capture(3)
 	}
 
-	if defined.traced {
-		e.trace = unsafe.Stack(3)
+	if len(defined.hooks) != 0 || hooks.Load() != nil {
+		defined.runHooks(event, e)
 	}
 
 	return e
 }
 
+func (defined *errorDefined) newErrorUntraced(parent error) *errorImpl {
+	return &errorImpl{
+		source:      defined,
+		parent:      parent,
+		sourceProps: defined.props,
+		explanation: strings.Builder{},
+	}
+}
+
 func (defined *errorDefined) Error() string {
 	panic("oops: do not use ErrorDefined as error, use ErrorDefined.Yeet and ErrorDefined.Wrap")
 }
 
 func (defined *errorDefined) Yeet() Error { //nolint:ireturn
-	return defined.newError(nil)
+	return defined.newError(OnYeet, nil)
 }
 
 func (defined *errorDefined) Yeetf(format string, args ...any) Error { //nolint:ireturn
-	err := defined.newError(nil)
-	err.Explainf(format, args...)
+	err := defined.newError(OnYeet, nil)
+	err.explain(0, format, args...)
 
 	return err
 }
 
 func (defined *errorDefined) Wrap(err error) Error { //nolint:ireturn
-	return defined.newError(err)
+	event := OnYeet
+	if err != nil {
+		event = OnWrap
+	}
+
+	e := defined.newError(event, err)
+	if err != nil {
+		e.returns = []errorReturn{{pc: unsafe.Caller(2), entry: -1}}
+	}
+
+	return e
 }
 
 func (defined *errorDefined) Wrapf(other error, format string, args ...any) Error { //nolint:ireturn
-	err := defined.newError(other)
-	err.Explainf(format, args...)
+	var pc uintptr
+
+	event := OnYeet
+	if other != nil {
+		pc = unsafe.Caller(2)
+		event = OnWrap
+	}
+
+	err := defined.newError(event, other)
+	err.explain(pc, format, args...)
 
 	return err
 }
@@ -70,35 +97,42 @@ func (defined *errorDefined) Collect() (ErrorCollectorFinish, ErrorCollectorAdd)
 			return nil
 		}
 
-		err := defined.newError(nil)
+		err := defined.newError(OnCollect, nil)
 		err.nested = errs
 
 		return err
 	}
 
 	addf := func(err error, path string, args ...any) {
-		if err == nil {
+		v := collectable(err)
+		if v == nil {
 			return
 		}
 
-		v, ok := err.(Error) //nolint:errorlint
-		if !ok {
-			vd, ok := err.(ErrorDefined) //nolint:errorlint
-			if !ok {
-				panic("oops: uncaught unwrapped error")
-			}
+		errs = append(errs, v.PathSetf(path, args...))
+	}
 
-			v = vd.Yeet()
-		}
+	return finish, addf
+}
 
-		if v == nil {
-			return
+// collectable returns the given error as an Error to be added to a collector, or nil if there is nothing to add
+// (including typed nil errors, see Normalize). It panics if the given error is neither an Error nor an ErrorDefined.
+func collectable(err error) Error { //nolint:ireturn
+	if isNilError(err) {
+		return nil
+	}
+
+	v, ok := err.(Error) //nolint:errorlint
+	if !ok {
+		vd, ok := err.(ErrorDefined) //nolint:errorlint
+		if !ok {
+			panic("oops: uncaught unwrapped error")
 		}
 
-		errs = append(errs, v.PathSetf(path, args...))
+		v = vd.Yeet()
 	}
 
-	return finish, addf
+	return v
 }
 
 func (defined *errorDefined) Is(other error) bool {
@@ -108,7 +142,28 @@ func (defined *errorDefined) Is(other error) bool {
 
 	vOther, ok := other.(Error)
 	if ok {
-		return vOther.Source() == defined
+		return sourceIs(vOther.Source(), defined)
+	}
+
+	return false
+}
+
+// sourceIs returns true if the source is the target, or if the source descends from the target (see
+// errorDefined.Child).
+func sourceIs(source, target ErrorDefined) bool {
+	if source == target {
+		return true
+	}
+
+	vSource, ok := source.(*errorDefined)
+	if !ok || vSource == nil {
+		return false
+	}
+
+	for defined := vSource.parent; defined != nil; defined = defined.parent {
+		if ErrorDefined(defined) == target {
+			return true
+		}
 	}
 
 	return false
@@ -261,6 +261,23 @@ func TestErrorDefined_Collect_none(t *testing.T) {
 			t.Fatal("err must be nil")
 		}
 	})
+
+	t.Run("typed nil", func(t *testing.T) {
+		t.Parallel()
+
+		finish, addf := errTest.Collect()
+		addf(oops.NilErr, "nil")
+
+		finishSync, addfSync := errTest.CollectSync(oops.CollectOrderInsertion)
+		addfSync(oops.NilErr, "nil")
+
+		finishIndexed, addfIndexed := errTest.CollectIndexed()
+		addfIndexed(0, oops.NilErr, "nil")
+
+		if finish() != nil || finishSync() != nil || finishIndexed() != nil {
+			t.Fatal("expected NilErr not to be collected")
+		}
+	})
 }
 
 func TestDefine_oddArgsPanic(t *testing.T) {
@@ -272,7 +289,7 @@ func TestDefine_oddArgsPanic(t *testing.T) {
 		}
 	}()
 
-	_ = oops.Define("only_key")
+	_ = oops.Define("only_key") //nolint:oopsvet // checks the panic
 }
 
 func TestErrorDefined_Is(t *testing.T) {
@@ -292,3 +309,22 @@ func TestErrorDefined_Is(t *testing.T) {
 		t.Fatal("errTest.Is(nil)")
 	}
 }
+
+var errTestProps = oops.Define(
+	"code", "test.props",
+	"status", 400,
+	"type", "https://example.com/problems/test",
+	"title", "test props",
+	"retryable", false,
+	"severity", "warning",
+)
+
+func BenchmarkErrorDefined_Yeet(b *testing.B) {
+	b.ReportAllocs()
+
+	for iter := 0; iter <= b.N; iter++ {
+		if err := errTestProps.Yeet(); err == nil {
+			b.Fatal("expected error")
+		}
+	}
+}
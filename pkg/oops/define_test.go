@@ -1,6 +1,7 @@
 package oops_test
 
 import (
+	"strings"
 	"testing"
 
 	"go.sdls.io/oops/pkg/oops"
@@ -24,3 +25,50 @@ func TestDefine_defaultFormatter(t *testing.T) {
 		t.Fatalf("oops.NilErr.Error() = %v, want %v", got, want)
 	}
 }
+
+func TestDefinedID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("code", func(t *testing.T) {
+		t.Parallel()
+
+		defined := oops.Define("code", "test.defined_id", "status", 404)
+		if id := oops.DefinedID(defined); id != "test.defined_id" {
+			t.Fatalf("unexpected id %q", id)
+		}
+
+		if id := oops.DefinedID(defined.Child("code", "test.defined_id.child")); id != "test.defined_id.child" {
+			t.Fatalf("unexpected id %q", id)
+		}
+
+		if id := oops.DefinedID(defined.Child("status", 410)); !strings.HasPrefix(id, "go.sdls.io/oops/pkg/oops_test/") {
+			t.Fatalf("expected children inheriting the code to be identified by their site, got %q", id)
+		}
+	})
+
+	t.Run("site", func(t *testing.T) {
+		t.Parallel()
+
+		first, second := oops.Define("type", "test.defined_id"), oops.Define("type", "test.defined_id")
+
+		id := oops.DefinedID(first)
+		if !strings.HasPrefix(id, "go.sdls.io/oops/pkg/oops_test/define_test.go:") {
+			t.Fatalf("unexpected id %q", id)
+		}
+
+		firstLine, _, _ := strings.Cut(id, "#")
+		secondLine, _, _ := strings.Cut(oops.DefinedID(second), "#")
+
+		if oops.DefinedID(second) == id || secondLine != firstLine {
+			t.Fatalf("expected the definitions of the same line to be told apart, got %q", oops.DefinedID(second))
+		}
+	})
+
+	t.Run("preset", func(t *testing.T) {
+		t.Parallel()
+
+		if id := oops.DefinedID(oops.ErrUncaught); id != "oops.ErrUncaught" {
+			t.Fatalf("unexpected id %q", id)
+		}
+	})
+}
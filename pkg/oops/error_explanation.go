@@ -0,0 +1,53 @@
+package oops
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ExplanationEntry is a single fragment of the explanation of an Error, as given to Explainf (the function or the
+// Error method), Yeetf or Wrapf, along with the time it was added. The Format is the template of the fragment, such
+// that errors can be grouped by it regardless of the Args.
+type ExplanationEntry struct {
+	Format string
+	Args   []any
+	Time   time.Time
+
+	text      string
+	formatted bool
+}
+
+// String returns the formatted fragment. As with Explainf, the Format is used verbatim if there are no Args. Unless
+// the definition snapshots its explanations (see Snapshot) or an arg formats itself (such as an error or a
+// fmt.Stringer), the fragment is formatted with the current values of the Args, when first needed.
+func (entry ExplanationEntry) String() string {
+	switch {
+	case entry.formatted:
+		return entry.text
+	case len(entry.Args) == 0:
+		return entry.Format
+	default:
+		return fmt.Sprintf(entry.Format, entry.Args...)
+	}
+}
+
+// Explanations returns (a copy of) the explanation fragments of the Error, in the order they were added.
+// Error.Explanation is these fragments joined with ", ".
+func (err *errorImpl) Explanations() []ExplanationEntry {
+	err.explanationMu.Lock()
+	defer err.explanationMu.Unlock()
+
+	return slices.Clone(err.explanations)
+}
+
+// Explanations returns the explanation fragments (see ExplanationEntry) of the given error, if it's an Error created
+// by oops. Explanations does not check the unwrap chain.
+func Explanations(err error) []ExplanationEntry {
+	v, ok := err.(*errorImpl) //nolint:errorlint
+	if !ok || v == nil {
+		return nil
+	}
+
+	return v.Explanations()
+}
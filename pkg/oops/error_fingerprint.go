@@ -0,0 +1,97 @@
+package oops
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FingerprintOption changes what Fingerprint is built from.
+type FingerprintOption func(fingerprint *fingerprinter)
+
+// FingerprintTrace makes Fingerprint also use the top given number of frames of the Error.Trace of each Error, without
+// their line numbers (such that the fingerprint survives unrelated changes of the source files).
+func FingerprintTrace(frames int) FingerprintOption {
+	return func(fingerprint *fingerprinter) {
+		fingerprint.frames = frames
+	}
+}
+
+type fingerprinter struct {
+	frames int
+	hash   hash.Hash
+}
+
+// Fingerprint returns a stable hash of the given error, grouping the occurrences of the "same" error: it's built from
+// the source definitions (identified by their code, or by the location of their Define call, see DefinedID), the
+// explanation formats (see ExplanationEntry, but not their args), the paths, and the structure of the tree of the
+// error (see Walk), but not from the messages of the errors that are not an Error (only from their types). Returns an
+// empty string for nil errors.
+func Fingerprint(err error, options ...FingerprintOption) string {
+	if Normalize(err) == nil {
+		return ""
+	}
+
+	fingerprint := &fingerprinter{hash: sha256.New()}
+	for _, option := range options {
+		option(fingerprint)
+	}
+
+	walk(err, walkAll, fingerprint.enter, fingerprint.leave)
+
+	return hex.EncodeToString(fingerprint.hash.Sum(nil)[:8])
+}
+
+func (fingerprint *fingerprinter) enter(err error, _ int, _ []string) bool {
+	w := fingerprint.hash
+
+	v, ok := err.(Error) //nolint:errorlint
+	if !ok {
+		_, _ = fmt.Fprintf(w, "{%T\x00", err)
+		return true
+	}
+
+	_, _ = io.WriteString(w, "{"+DefinedID(v.Source())+"\x00"+v.Path()+"\x00")
+
+	for _, entry := range Explanations(v) {
+		_, _ = io.WriteString(w, entry.Format+"\x00")
+	}
+
+	trace := v.Trace()
+	for idx := 0; idx < fingerprint.frames && idx < len(trace); idx++ {
+		_, _ = io.WriteString(w, fingerprintFrame(trace[idx])+"\x00")
+	}
+
+	return true
+}
+
+func (fingerprint *fingerprinter) leave(error, int, []string) bool {
+	_, _ = io.WriteString(fingerprint.hash, "}")
+	return true
+}
+
+// fingerprintFrame returns the file name and the function of a frame of Error.Trace, without the directory and the
+// line number.
+func fingerprintFrame(frame string) string {
+	location, function, ok := strings.Cut(frame, "): ")
+	if !ok {
+		return frame
+	}
+
+	if paren := strings.LastIndex(location, " ("); paren >= 0 {
+		location = location[:paren]
+	}
+
+	if colon := strings.LastIndex(location, ":"); colon >= 0 {
+		if _, err := strconv.Atoi(location[colon+1:]); err == nil {
+			location = location[:colon]
+		}
+	}
+
+	return filepath.Base(location) + ": " + function
+}
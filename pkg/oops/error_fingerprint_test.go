@@ -0,0 +1,144 @@
+package oops_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var (
+	errTestFingerprint      = oops.Define("code", "test.fingerprint").Trace()
+	errTestFingerprintOther = oops.Define("code", "test.fingerprint.other")
+
+	errTestFingerprintPointer = oops.Define("code", "test.fingerprint.pointer", "ptr", new(int))
+	errTestFingerprintNoCode  = oops.Define("type", "test.fingerprint.no_code")
+)
+
+func fingerprintNotFound(id int) error {
+	return errTestFingerprint.Yeetf("user %d not found", id)
+}
+
+func fingerprintNotFoundElsewhere(id int) error {
+	return errTestFingerprint.Yeetf("user %d not found", id)
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("args", func(t *testing.T) {
+		t.Parallel()
+
+		if a, b := oops.Fingerprint(fingerprintNotFound(1)), oops.Fingerprint(fingerprintNotFound(2)); a != b || a == "" {
+			t.Fatalf("expected the same fingerprint, got %q and %q", a, b)
+		}
+	})
+
+	t.Run("different", func(t *testing.T) {
+		t.Parallel()
+
+		base := oops.Fingerprint(errTestFingerprint.Yeetf("user %d not found", 1))
+
+		others := map[string]error{
+			"definition":  errTestFingerprintOther.Yeetf("user %d not found", 1),
+			"format":      errTestFingerprint.Yeetf("user %d gone", 1),
+			"path":        errTestFingerprint.Yeetf("user %d not found", 1).PathSetf("users"),
+			"nested":      errTestFingerprint.Yeetf("user %d not found", 1).Append(errTestFingerprintOther.Yeet()),
+			"parent":      errTestFingerprint.Wrapf(errors.New("foreign"), "user %d not found", 1),
+			"explanation": oops.Explainf(errTestFingerprint.Yeetf("user %d not found", 1), "again"),
+		}
+
+		for name, err := range others {
+			if oops.Fingerprint(err) == base {
+				t.Fatalf("expected a different fingerprint for %s", name)
+			}
+		}
+	})
+
+	t.Run("collision", func(t *testing.T) {
+		t.Parallel()
+
+		if oops.Fingerprint(oops.Define().Yeet()) == oops.Fingerprint(oops.Define().Yeet()) {
+			t.Fatal("expected distinct definitions without props to have different fingerprints")
+		}
+
+		a, b := oops.Define("type", "test.fingerprint.same"), oops.Define("type", "test.fingerprint.same")
+		if oops.Fingerprint(a.Yeet()) == oops.Fingerprint(b.Yeet()) {
+			t.Fatal("expected distinct definitions with the same props but no code to have different fingerprints")
+		}
+
+		defined := make([]oops.ErrorDefined, 2)
+		for idx := range defined {
+			defined[idx] = errTestFingerprint.Child("ptr", &idx)
+		}
+
+		if oops.Fingerprint(defined[0].Yeet()) == oops.Fingerprint(defined[1].Yeet()) {
+			t.Fatal("expected definitions created at the same line to have different fingerprints")
+		}
+	})
+
+	t.Run("stable", func(t *testing.T) {
+		t.Parallel()
+
+		// the definition is identified by its code, neither by its location nor by the addresses of its props
+		defined := oops.Define("code", "test.fingerprint.pointer", "ptr", new(int))
+		if a, b := oops.Fingerprint(errTestFingerprintPointer.Yeet()), oops.Fingerprint(defined.Yeet()); a != b {
+			t.Fatalf("expected the same fingerprint, got %q and %q", a, b)
+		}
+
+		// without a code, by its location
+		a, b := oops.Fingerprint(errTestFingerprintNoCode.Yeet()), oops.Fingerprint(errTestFingerprintNoCode.Yeet())
+		if a != b {
+			t.Fatalf("expected the same fingerprint, got %q and %q", a, b)
+		}
+	})
+
+	t.Run("structure", func(t *testing.T) {
+		t.Parallel()
+
+		a := errTestFingerprint.Yeet().Append(errTestFingerprintOther.Yeet().Append(errTestFingerprint.Yeet()))
+		b := errTestFingerprint.Yeet().Append(errTestFingerprintOther.Yeet(), errTestFingerprint.Yeet())
+
+		if oops.Fingerprint(a) == oops.Fingerprint(b) {
+			t.Fatal("expected the structure of nested errors to change the fingerprint")
+		}
+	})
+
+	t.Run("foreign", func(t *testing.T) {
+		t.Parallel()
+
+		a := errTestFingerprintOther.Wrap(fmt.Errorf("user %d: %w", 1, errors.ErrUnsupported))
+		b := errTestFingerprintOther.Wrap(fmt.Errorf("user %d: %w", 2, errors.ErrUnsupported))
+
+		if oops.Fingerprint(a) != oops.Fingerprint(b) {
+			t.Fatal("expected foreign messages to be ignored")
+		}
+	})
+
+	t.Run("trace", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := fingerprintNotFound(1), fingerprintNotFoundElsewhere(1)
+		if oops.Fingerprint(a) != oops.Fingerprint(b) {
+			t.Fatal("expected traces to be ignored by default")
+		}
+
+		trace := oops.FingerprintTrace(1)
+		if oops.Fingerprint(a, trace) == oops.Fingerprint(b, trace) {
+			t.Fatal("expected the top frame to change the fingerprint")
+		}
+
+		if oops.Fingerprint(a, trace) != oops.Fingerprint(fingerprintNotFound(2), trace) {
+			t.Fatal("expected the same frames to keep the fingerprint")
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		if fingerprint := oops.Fingerprint(oops.NilErr); fingerprint != "" {
+			t.Fatalf("expected no fingerprint, got %q", fingerprint)
+		}
+	})
+}
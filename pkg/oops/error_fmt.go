@@ -0,0 +1,270 @@
+package oops
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ fmt.Formatter  = &errorImpl{}
+	_ fmt.GoStringer = &errorDefined{}
+)
+
+// Format implements fmt.Formatter. The %s and %v verbs print the Error output (as created by the Formatter of the
+// ErrorDefined), %q prints the same output quoted. The %+v verb prints the whole error tree (explanation, path, props,
+// trace, return trace, nested errors and parent errors), indented with tabs. The %#v verb prints a Go-syntax-like
+// representation of the error, intended for debugging.
+func (err *errorImpl) Format(state fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		switch {
+		case state.Flag('+'):
+			printer := errorPrinter{w: state, seen: make(map[error]struct{})}
+			printer.tree(err, 0)
+		case state.Flag('#'):
+			printer := errorPrinter{w: state, seen: make(map[error]struct{})}
+			printer.goSyntax(err)
+		default:
+			_, _ = io.WriteString(state, err.Error())
+		}
+	case 's':
+		_, _ = io.WriteString(state, err.Error())
+	case 'q':
+		_, _ = io.WriteString(state, strconv.Quote(err.Error()))
+	default:
+		_, _ = fmt.Fprintf(state, "%%!%c(oops.Error=%s)", verb, err.Error())
+	}
+}
+
+type errorPrinter struct {
+	w    io.Writer
+	seen map[error]struct{}
+}
+
+// enter marks the error as being printed, returning false if the error is already being printed (ie: it contains
+// itself).
+func (printer errorPrinter) enter(err error) bool {
+	if !reflect.TypeOf(err).Comparable() {
+		return true
+	}
+
+	if _, ok := printer.seen[err]; ok {
+		return false
+	}
+
+	printer.seen[err] = struct{}{}
+
+	return true
+}
+
+func (printer errorPrinter) leave(err error) {
+	if reflect.TypeOf(err).Comparable() {
+		delete(printer.seen, err)
+	}
+}
+
+func (printer errorPrinter) line(depth int, format string, args ...any) {
+	_, _ = io.WriteString(printer.w, strings.Repeat("\t", depth))
+	_, _ = fmt.Fprintf(printer.w, format, args...)
+	_, _ = io.WriteString(printer.w, "\n")
+}
+
+func (printer errorPrinter) tree(err error, depth int) {
+	if !printer.enter(err) {
+		printer.line(depth, "<cycle>")
+		return
+	}
+	defer printer.leave(err)
+
+	v, ok := err.(Error) //nolint:errorlint
+	if !ok {
+		printer.line(depth, "%s", err.Error())
+
+		if parent := errors.Unwrap(err); parent != nil {
+			printer.line(depth+1, "parent:")
+			printer.tree(parent, depth+2)
+		}
+
+		return
+	}
+
+	if v == NilErr {
+		printer.line(depth, "%s", NilErr.Error())
+		return
+	}
+
+	printer.line(depth, "%s", v.Error())
+
+	if explanation := v.Explanation(); explanation != "" {
+		printer.line(depth+1, "explanation: %s", explanation)
+	}
+
+	if path := v.Path(); path != "" {
+		printer.line(depth+1, "path: %s", path)
+	}
+
+	if props := v.GetAll(); len(props) != 0 {
+		printer.line(depth+1, "props:")
+		for _, key := range sortedKeys(props) {
+			printer.line(depth+2, "%s: %v", key, props[key])
+		}
+	}
+
+	if trace := v.Trace(); len(trace) != 0 {
+		printer.line(depth+1, "trace:")
+		for _, frame := range trace {
+			printer.line(depth+2, "%s", frame)
+		}
+	}
+
+	if vi, ok := v.(*errorImpl); ok && len(vi.returns) != 0 {
+		printer.line(depth+1, "returns:")
+		for _, frame := range vi.returnFrames() {
+			if frame.Explanation == "" {
+				printer.line(depth+2, "%s:%d (%s)", frame.File, frame.Line, frame.Function)
+			} else {
+				printer.line(depth+2, "%s:%d (%s): %s", frame.File, frame.Line, frame.Function, frame.Explanation)
+			}
+		}
+	}
+
+	if nested := v.Nested(); len(nested) != 0 {
+		printer.line(depth+1, "nested:")
+		for _, n := range nested {
+			if n == nil {
+				continue
+			}
+
+			printer.tree(n, depth+2)
+		}
+	}
+
+	if parent := parentOf(v); parent != nil {
+		printer.line(depth+1, "parent:")
+		printer.tree(parent, depth+2)
+	}
+}
+
+func (printer errorPrinter) goSyntax(err error) {
+	v, ok := err.(Error) //nolint:errorlint
+	if !ok {
+		_, _ = fmt.Fprintf(printer.w, "%#v", err)
+		return
+	}
+
+	if v == NilErr {
+		_, _ = io.WriteString(printer.w, "oops.Error(nil)")
+		return
+	}
+
+	if !printer.enter(err) {
+		_, _ = io.WriteString(printer.w, "oops.Error(<cycle>)")
+		return
+	}
+	defer printer.leave(err)
+
+	fields := make([]string, 0, 8)
+	field := func(name string, format string, args ...any) {
+		fields = append(fields, name+": "+fmt.Sprintf(format, args...))
+	}
+
+	field("Source", "%s", goSyntaxDefined(v.Source()))
+
+	if explanation := v.Explanation(); explanation != "" {
+		field("Explanation", "%q", explanation)
+	}
+
+	if path := v.Path(); path != "" {
+		field("Path", "%q", path)
+	}
+
+	if pathArgs := v.PathArgs(); len(pathArgs) != 0 {
+		field("PathArgs", "%#v", pathArgs)
+	}
+
+	if props := v.GetAll(); len(props) != 0 {
+		field("Props", "%s", goSyntaxProps(props))
+	}
+
+	if trace := v.Trace(); len(trace) != 0 {
+		field("Trace", "%#v", trace)
+	}
+
+	_, _ = io.WriteString(printer.w, "&oops.Error{"+strings.Join(fields, ", "))
+
+	if nested := v.Nested(); len(nested) != 0 {
+		_, _ = io.WriteString(printer.w, ", Nested: []oops.Error{")
+		for idx, n := range nested {
+			if idx != 0 {
+				_, _ = io.WriteString(printer.w, ", ")
+			}
+
+			if n == nil {
+				_, _ = io.WriteString(printer.w, "nil")
+				continue
+			}
+
+			printer.goSyntax(n)
+		}
+		_, _ = io.WriteString(printer.w, "}")
+	}
+
+	if parent := parentOf(v); parent != nil {
+		_, _ = io.WriteString(printer.w, ", Parent: ")
+		printer.goSyntax(parent)
+	}
+
+	_, _ = io.WriteString(printer.w, "}")
+}
+
+// GoString implements fmt.GoStringer, printing the definition as the call to Define creating it (eg: for %#v).
+func (defined *errorDefined) GoString() string {
+	return goSyntaxDefined(defined)
+}
+
+func goSyntaxDefined(defined ErrorDefined) string {
+	switch defined {
+	case nil:
+		return "nil"
+	case ErrTODO:
+		return "oops.ErrTODO"
+	case ErrUncaught:
+		return "oops.ErrUncaught"
+	}
+
+	vd, ok := defined.(*errorDefined)
+	if !ok {
+		return fmt.Sprintf("%T", defined)
+	}
+
+	args := make([]string, 0, 2*len(vd.props))
+	for _, key := range sortedKeys(vd.props) {
+		args = append(args, strconv.Quote(key), fmt.Sprintf("%#v", vd.props[key]))
+	}
+
+	return "oops.Define(" + strings.Join(args, ", ") + ")"
+}
+
+func goSyntaxProps(props map[string]any) string {
+	pairs := make([]string, 0, len(props))
+	for _, key := range sortedKeys(props) {
+		pairs = append(pairs, fmt.Sprintf("%q: %#v", key, props[key]))
+	}
+
+	return "map[string]any{" + strings.Join(pairs, ", ") + "}"
+}
+
+func sortedKeys(props map[string]any) []string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
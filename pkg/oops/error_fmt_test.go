@@ -0,0 +1,150 @@
+package oops_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+func TestError_Format(t *testing.T) {
+	t.Parallel()
+
+	t.Run("simple verbs", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTest.Yeetf("foo %s", "bar")
+
+		tests := map[string]string{
+			"%v":   "foo bar",
+			"%s":   "foo bar",
+			"%q":   `"foo bar"`,
+			"%d":   "%!d(oops.Error=foo bar)",
+			"%10v": "foo bar",
+		}
+
+		for format, want := range tests {
+			if got := fmt.Sprintf(format, err); got != want {
+				t.Fatalf("fmt.Sprintf(%q) = %q, want %q", format, got, want)
+			}
+		}
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		t.Parallel()
+
+		finish, addf := errTestBenchmark.Collect()
+		addf(errTest.Yeetf("too short"), "name")
+
+		_, file, line, _ := runtime.Caller(0)
+
+		err := finish()
+		err.Explainf("validating")
+		_ = err.Append(errTest.Wrap(fmt.Errorf("query: %w", errors.New("refused"))))
+
+		want := strings.Join([]string{
+			"validating",
+			"\texplanation: validating",
+			"\tprops:",
+			"\t\tcode: test.err_test_benchmark",
+			"\t\tstatus: 418",
+			"\treturns:",
+			fmt.Sprintf("\t\t%s:%d (TestError_Format.func2): validating", file, line+3),
+			"\tnested:",
+			"\t\ttoo short",
+			"\t\t\texplanation: too short",
+			"\t\t\tpath: name",
+			"\t\t\tprops:",
+			"\t\t\t\tcode: test.err_test",
+			"\t\toops.Error",
+			"\t\t\tprops:",
+			"\t\t\t\tcode: test.err_test",
+			"\t\t\treturns:",
+			fmt.Sprintf("\t\t\t\t%s:%d (TestError_Format.func2)", file, line+4),
+			"\t\t\tparent:",
+			"\t\t\t\tquery: refused",
+			"\t\t\t\t\tparent:",
+			"\t\t\t\t\t\trefused",
+			"",
+		}, "\n")
+
+		if got := fmt.Sprintf("%+v", err); got != want {
+			t.Fatalf("unexpected tree\n got: %q\nwant: %q", got, want)
+		}
+	})
+
+	t.Run("tree trace", func(t *testing.T) {
+		t.Parallel()
+
+		got := fmt.Sprintf("%+v", errTestTrace.Yeet())
+		if !strings.Contains(got, "\ttrace:\n\t\t") || !strings.Contains(got, "error_fmt_test.go") {
+			t.Fatalf("expected trace in tree, got %q", got)
+		}
+	})
+
+	t.Run("tree cycle", func(t *testing.T) {
+		t.Parallel()
+
+		err := oops.Define().Yeet()
+		_ = err.Append(err)
+
+		want := "oops.Error\n\tnested:\n\t\t<cycle>\n"
+		if got := fmt.Sprintf("%+v", err); got != want {
+			t.Fatalf("unexpected tree\n got: %q\nwant: %q", got, want)
+		}
+	})
+
+	t.Run("go syntax", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTestBenchmark.Wrapf(errors.New("refused"), "id=%d", 7)
+		_ = err.PathSetf("users/%d", 7)
+		_ = err.Append(errTest.Yeet())
+
+		want := `&oops.Error{Source: oops.Define("code", "test.err_test_benchmark", "status", 418), ` +
+			`Explanation: "id=7", Path: "users/7", PathArgs: []interface {}{7}, ` +
+			`Props: map[string]any{"code": "test.err_test_benchmark", "status": 418}, ` +
+			`Nested: []oops.Error{&oops.Error{Source: oops.Define("code", "test.err_test"), ` +
+			`Props: map[string]any{"code": "test.err_test"}}}, ` +
+			`Parent: &errors.errorString{s:"refused"}}`
+
+		if got := fmt.Sprintf("%#v", err); got != want {
+			t.Fatalf("unexpected go syntax\n got: %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("go syntax preset", func(t *testing.T) {
+		t.Parallel()
+
+		got := fmt.Sprintf("%#v", oops.MustAny(errors.New("plain")))
+		if !strings.HasPrefix(got, "&oops.Error{Source: oops.ErrUncaught, Trace: []string{") {
+			t.Fatalf("unexpected go syntax: %s", got)
+		}
+	})
+
+	t.Run("go syntax defined", func(t *testing.T) {
+		t.Parallel()
+
+		want := `oops.Define("code", "test.err_test_benchmark", "status", 418)`
+		if got := fmt.Sprintf("%#v", errTestBenchmark); got != want {
+			t.Fatalf("unexpected go syntax\n got: %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		for format, want := range map[string]string{
+			"%v":  "oops.Error(nil)",
+			"%+v": "oops.Error(nil)\n",
+			"%#v": "oops.Error(nil)",
+		} {
+			if got := fmt.Sprintf(format, oops.NilErr); got != want {
+				t.Fatalf("fmt.Sprintf(%q) = %q, want %q", format, got, want)
+			}
+		}
+	})
+}
@@ -2,8 +2,19 @@ package oops
 
 import (
 	"errors"
+	"strings"
+	"sync/atomic"
 )
 
+// unwrapNested is set by SetUnwrapNested.
+var unwrapNested atomic.Bool
+
+// SetUnwrapNested makes the errors of all definitions expose their Error.Nested errors to errors.Is and errors.As (see
+// the UnwrapNested builder), or only those of the definitions opting in if false.
+func SetUnwrapNested(enabled bool) {
+	unwrapNested.Store(enabled)
+}
+
 func (err *errorImpl) Error() string {
 	if err == nil {
 		return "oops.Error(nil)"
@@ -23,8 +34,64 @@ func (err *errorImpl) Error() string {
 	return err.source.formatter(err)
 }
 
+// Unwrap returns the parent error. If the source definition (or SetUnwrapNested) exposes the Error.Nested errors, and
+// there are any, Unwrap instead returns an error unwrapping into the parent (if any) followed by the nested errors, as
+// with errors.Join. The nested errors being the Error itself are skipped, as they would be unwrapped endlessly.
 func (err *errorImpl) Unwrap() error {
-	return err.parent
+	if len(err.nested) == 0 || (!err.source.unwrapNested && !unwrapNested.Load()) {
+		return err.parent
+	}
+
+	errs := make([]error, 0, len(err.nested)+1)
+	if err.parent != nil {
+		errs = append(errs, err.parent)
+	}
+
+	for _, nested := range err.nested {
+		if !isNilError(nested) && nested != Error(err) {
+			errs = append(errs, nested)
+		}
+	}
+
+	return &errorUnwrapped{errs: errs}
+}
+
+// errorUnwrapped is returned by Error.Unwrap to expose the parent and the nested errors of an Error through the
+// Unwrap() []error interface.
+type errorUnwrapped struct {
+	errs []error
+}
+
+func (err *errorUnwrapped) Error() string {
+	messages := make([]string, len(err.errs))
+	for idx, e := range err.errs {
+		messages[idx] = e.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func (err *errorUnwrapped) Unwrap() []error {
+	return err.errs
+}
+
+// parentOf returns the parent of the given Error, being Error.Unwrap unless it also exposes the nested errors.
+func parentOf(v Error) error {
+	if vi, ok := v.(*errorImpl); ok {
+		return vi.parent
+	}
+
+	return v.Unwrap()
+}
+
+// unwrapParent returns the parent of the given error, as errors.Unwrap does, but never the nested errors exposed by an
+// Error (see parentOf).
+func unwrapParent(err error) error {
+	if v, ok := err.(Error); ok { //nolint:errorlint
+		return parentOf(v)
+	}
+
+	return errors.Unwrap(err)
 }
 
 func (err *errorImpl) Is(other error) bool {
@@ -34,7 +101,7 @@ func (err *errorImpl) Is(other error) bool {
 
 	vOther, ok := other.(ErrorDefined)
 	if ok {
-		return err.source == vOther
+		return sourceIs(err.source, vOther)
 	}
 
 	vErr, ok := other.(Error)
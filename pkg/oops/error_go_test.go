@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
+	"strings"
 	"testing"
 
 	"go.sdls.io/oops/pkg/oops"
@@ -241,3 +243,79 @@ func TestError_Join(t *testing.T) {
 		t.Fatal("expected error to contain odd")
 	}
 }
+
+var (
+	errTestUnwrapField     = oops.Define("code", "test.unwrap.field")
+	errTestUnwrapCollected = oops.Define("code", "test.unwrap.collected").UnwrapNested()
+	errTestUnwrapOff       = oops.Define("code", "test.unwrap.off")
+)
+
+type testUnwrapTarget struct{ error }
+
+func TestError_UnwrapNested(t *testing.T) {
+	t.Parallel()
+
+	parent := &testUnwrapTarget{errors.New("parent")}
+	err := errTestUnwrapCollected.Wrap(parent).Append(errTestUnwrapField.Yeet(), oops.NilErr)
+
+	if !errors.Is(err, errTestUnwrapField) {
+		t.Fatal("expected errors.Is to check the nested errors")
+	}
+
+	var target *testUnwrapTarget
+	if !errors.As(err, &target) || target != parent {
+		t.Fatal("expected errors.As to still reach the parent")
+	}
+
+	if errors.Is(errTestUnwrapOff.Yeet().Append(errTestUnwrapField.Yeet()), errTestUnwrapField) {
+		t.Fatal("expected nested errors to be hidden by default")
+	}
+
+	if errors.Is(errTestUnwrapCollected.Child().Yeet().Append(errTestUnwrapOff.Yeet()), errTestUnwrapField) {
+		t.Fatal("expected no match")
+	}
+
+	if !errors.Is(errTestUnwrapCollected.Child().Yeet().Append(errTestUnwrapField.Yeet()), errTestUnwrapField) {
+		t.Fatal("expected children to inherit the unwrapping")
+	}
+
+	if tree := fmt.Sprintf("%+v", err); strings.Count(tree, "test.unwrap.field") != 1 {
+		t.Fatalf("expected the nested error once in the tree, got:\n%s", tree)
+	}
+}
+
+func TestError_UnwrapNested_self(t *testing.T) {
+	t.Parallel()
+
+	err := errTestUnwrapCollected.Yeet()
+	err.Append(err, errTestUnwrapField.Yeet()) //nolint:oopsvet // errorImpl appends to the receiver
+
+	if !errors.Is(err, errTestUnwrapField) {
+		t.Fatal("expected errors.Is to check the other nested errors")
+	}
+
+	if errors.Is(err, errTestUnwrapOff) {
+		t.Fatal("expected no match")
+	}
+}
+
+// TestSetUnwrapNested is not parallel, as it changes the behaviour of all errors.
+func TestSetUnwrapNested(t *testing.T) {
+	err := errTestUnwrapOff.Yeet().Append(errTestUnwrapField.Yeet())
+
+	oops.SetUnwrapNested(true)
+	defer oops.SetUnwrapNested(false)
+
+	if !errors.Is(err, errTestUnwrapField) {
+		t.Fatal("expected errors.Is to check the nested errors")
+	}
+
+	wrapped := errTestUnwrapOff.Wrapf(oops.Explainf(errTest.Yeet(), "inner"), "outer").Append(errTestUnwrapField.Yeet())
+	if frames := oops.ReturnTrace(wrapped); len(frames) != 2 {
+		t.Fatalf("expected 2 return layers, got %v", frames)
+	}
+
+	if count := len(slices.Collect(oops.Chain(wrapped))); count != 2 {
+		t.Fatalf("expected a chain of 2 errors, got %d", count)
+	}
+}
@@ -2,7 +2,13 @@ package oops
 
 import (
 	"fmt"
+	"maps"
+	"reflect"
 	"strings"
+	"sync"
+	"time"
+
+	"go.sdls.io/oops/internal/unsafe"
 )
 
 var _ Error = &errorImpl{}
@@ -16,10 +22,20 @@ type errorImpl struct {
 
 	path     string
 	pathArgs []any
-	props    map[string]any
 
-	trace       []string
-	explanation strings.Builder
+	// props holds the props set on the error, shadowing the sourceProps (the props of the definition at the time the
+	// error was created), which are shared by the errors of the definition and never modified
+	props       map[string]any
+	sourceProps map[string]any
+
+	trace        *unsafe.Trace
+	returns      []errorReturn
+	explanations []ExplanationEntry
+
+	// explanation caches the formatted explanations, up to explained entries
+	explanationMu sync.Mutex
+	explanation   strings.Builder
+	explained     int
 }
 
 func (err *errorImpl) Nested() []Error {
@@ -31,10 +47,23 @@ func (err *errorImpl) Append(errs ...Error) Error { //nolint:ireturn
 	return err
 }
 
+// GetAll returns a new map holding the props of the definition, merged with the props set on the error.
 func (err *errorImpl) GetAll() map[string]any {
-	return err.props
+	switch {
+	case len(err.props) == 0:
+		return maps.Clone(err.sourceProps)
+	case len(err.sourceProps) == 0:
+		return maps.Clone(err.props)
+	}
+
+	merged := make(map[string]any, len(err.sourceProps)+len(err.props))
+	maps.Copy(merged, err.sourceProps)
+	maps.Copy(merged, err.props)
+
+	return merged
 }
 
+// Set sets the prop on the error only, the props of the definition are only read (see Get).
 func (err *errorImpl) Set(key string, value any) Error { //nolint:ireturn
 	if err.props == nil {
 		err.props = make(map[string]any, 4)
@@ -45,21 +74,50 @@ func (err *errorImpl) Set(key string, value any) Error { //nolint:ireturn
 	return err
 }
 
+// Get returns the prop set on the error, or else the prop of the definition.
 func (err *errorImpl) Get(key string) (value any, ok bool) {
-	if err.props == nil {
-		return nil, false
+	if value, ok = err.props[key]; ok {
+		return value, true
 	}
 
-	value, ok = err.props[key]
+	value, ok = err.sourceProps[key]
+
 	return value, ok
 }
 
 func (err *errorImpl) Explanation() string {
+	err.explanationMu.Lock()
+	defer err.explanationMu.Unlock()
+
+	for ; err.explained < len(err.explanations); err.explained++ {
+		text := err.explanationText(err.explained)
+		if text == "" {
+			continue
+		}
+
+		if err.explanation.Len() != 0 {
+			err.explanation.WriteString(", ")
+		}
+
+		err.explanation.WriteString(text)
+	}
+
 	return err.explanation.String()
 }
 
+// explanationText returns the formatted explanation entry at the given index, formatting it only once. The
+// explanationMu must be held.
+func (err *errorImpl) explanationText(idx int) string {
+	entry := &err.explanations[idx]
+	if !entry.formatted {
+		entry.text, entry.formatted = entry.String(), true
+	}
+
+	return entry.text
+}
+
 func (err *errorImpl) Trace() []string {
-	return err.trace
+	return err.trace.Frames()
 }
 
 func (err *errorImpl) Source() ErrorDefined { //nolint:ireturn
@@ -86,18 +144,106 @@ func (err *errorImpl) PathSetf(path string, args ...any) Error { //nolint:iretur
 }
 
 func (err *errorImpl) Explainf(format string, args ...any) {
-	if format == "" {
-		return
+	err.explain(unsafe.Caller(2), format, args...)
+}
+
+// explain adds the explanation fragment, recording the layer (see ReturnFrame) of the given program counter (unless
+// 0). The fragment is only formatted when first needed (see Explanation), unless the source definition snapshots the
+// explanations or an arg formats itself (see eagerArgs).
+func (err *errorImpl) explain(pc uintptr, format string, args ...any) {
+	entry := -1
+	if format != "" {
+		added := ExplanationEntry{
+			Format: format,
+			Args:   args,
+			Time:   time.Now(),
+		}
+
+		// formatted before being added, such that formatting the Error itself (through an arg) does not include it
+		switch {
+		case len(args) == 0:
+			added.text, added.formatted = format, true
+		case err.source != nil && err.source.snapshot, eagerArgs(args):
+			added.text, added.formatted = fmt.Sprintf(format, args...), true
+		}
+
+		entry = len(err.explanations)
+		err.explanations = append(err.explanations, added)
 	}
 
-	if err.explanation.Len() != 0 {
-		err.explanation.WriteString(", ")
+	if pc != 0 {
+		err.returns = append(err.returns, errorReturn{pc: pc, entry: entry})
 	}
+}
 
-	if len(args) == 0 {
-		err.explanation.WriteString(format)
-		return
+// eagerArgs returns true if an arg may format itself with a method (such as an error, possibly this very Error, or a
+// fmt.Stringer), or holds a value which may (see formatsItself). Such args are formatted when the fragment is added,
+// as the lazy formatting holds the explanationMu, which their methods could wait for by formatting the Error itself.
+func eagerArgs(args []any) bool {
+	for _, arg := range args {
+		if arg != nil && formatsItself(reflect.TypeOf(arg)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var (
+	formatMethods = []reflect.Type{
+		reflect.TypeFor[error](),
+		reflect.TypeFor[fmt.Stringer](),
+		reflect.TypeFor[fmt.Formatter](),
+		reflect.TypeFor[fmt.GoStringer](),
+	}
+
+	// formatsItselfTypes caches the results of formatsItself by type
+	formatsItselfTypes sync.Map
+)
+
+// formatsItself returns true if fmt may call a method of a value of the type (or of the values it holds) when
+// formatting it, being an interface (whose dynamic value is unknown) or a type implementing fmt.Formatter,
+// fmt.Stringer, fmt.GoStringer or error.
+func formatsItself(t reflect.Type) bool {
+	if cached, ok := formatsItselfTypes.Load(t); ok {
+		return cached.(bool) //nolint:forcetypeassert
 	}
 
-	err.explanation.WriteString(fmt.Sprintf(format, args...))
+	result := formatsItselfWalk(t, make(map[reflect.Type]struct{}))
+	formatsItselfTypes.Store(t, result)
+
+	return result
+}
+
+func formatsItselfWalk(t reflect.Type, seen map[reflect.Type]struct{}) bool {
+	if _, ok := seen[t]; ok {
+		return false
+	}
+
+	seen[t] = struct{}{}
+
+	for _, method := range formatMethods {
+		if t.Implements(method) {
+			return true
+		}
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return formatsItselfWalk(t.Elem(), seen)
+	case reflect.Map:
+		return formatsItselfWalk(t.Key(), seen) || formatsItselfWalk(t.Elem(), seen)
+	case reflect.Struct:
+		for idx := range t.NumField() {
+			if formatsItselfWalk(t.Field(idx).Type, seen) {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return false
+	}
 }
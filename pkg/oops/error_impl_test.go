@@ -2,7 +2,11 @@ package oops_test
 
 import (
 	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"go.sdls.io/oops/pkg/oops"
 )
@@ -30,6 +34,28 @@ func Test_stack(t *testing.T) {
 		for _, trace := range err.Trace() {
 			t.Log(trace)
 		}
+
+		if !strings.HasSuffix(err.Trace()[0], ": Test_stack.func2") {
+			t.Fatalf("expected trace to start at the caller of Yeet, got %s", err.Trace()[0])
+		}
+	})
+}
+
+func BenchmarkError_Trace(b *testing.B) {
+	b.Run("yeet", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for iter := 0; iter < b.N; iter++ {
+			_ = errTestTrace.Yeet()
+		}
+	})
+
+	b.Run("yeet and trace", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for iter := 0; iter < b.N; iter++ {
+			_ = errTestTrace.Yeet().Trace()
+		}
 	})
 }
 
@@ -96,6 +122,163 @@ func TestError_Explain(t *testing.T) {
 	}
 }
 
+func TestError_Explanations(t *testing.T) {
+	t.Parallel()
+
+	before := time.Now()
+
+	err := errTest.Wrapf(errors.New("refused"), "loading %s", "config")
+	err.Explainf("retrying")
+	err.Explainf("")
+	err.Explainf("attempt %d of %d", 2, 3)
+
+	entries := oops.Explanations(err)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	formats := []string{"loading %s", "retrying", "attempt %d of %d"}
+	texts := []string{"loading config", "retrying", "attempt 2 of 3"}
+
+	for idx, entry := range entries {
+		if entry.Format != formats[idx] || entry.String() != texts[idx] {
+			t.Fatalf("unexpected entry %d: %q (%q)", idx, entry.Format, entry.String())
+		}
+
+		if entry.Time.Before(before) {
+			t.Fatalf("unexpected entry %d time: %v", idx, entry.Time)
+		}
+	}
+
+	if len(entries[2].Args) != 2 || entries[2].Args[0] != 2 {
+		t.Fatalf("unexpected entry args: %v", entries[2].Args)
+	}
+
+	if err.Explanation() != "loading config, retrying, attempt 2 of 3" {
+		t.Fatalf("unexpected explanation: %q", err.Explanation())
+	}
+
+	if oops.Explanations(errors.New("foreign")) != nil {
+		t.Fatal("expected no entries for foreign errors")
+	}
+
+	entry := oops.ExplanationEntry{Format: "id=%d", Args: []any{7}}
+	if entry.String() != "id=7" {
+		t.Fatalf("unexpected entry string: %q", entry.String())
+	}
+}
+
+func TestError_Explanations_formatter(t *testing.T) {
+	t.Parallel()
+
+	errLayered := oops.Define("code", "test.layered").Formatter(func(err oops.Error) string {
+		entries := oops.Explanations(err)
+
+		layers := make([]string, len(entries))
+		for idx, entry := range entries {
+			layers[len(entries)-1-idx] = entry.String()
+		}
+
+		return strings.Join(layers, ": ")
+	})
+
+	err := errLayered.Yeetf("reading file")
+	err.Explainf("loading config")
+
+	if err.Error() != "loading config: reading file" {
+		t.Fatalf("unexpected error: %q", err.Error())
+	}
+}
+
+type countingStringer struct {
+	calls *atomic.Int32
+}
+
+func (s countingStringer) String() string {
+	s.calls.Add(1)
+	return "counted"
+}
+
+func TestError_Explanation_lazy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("formatted once", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		// args formatting themselves are formatted when added (see the "self" test)
+		err := errTest.Yeetf("value %s", countingStringer{calls: &calls})
+		if calls.Load() != 1 {
+			t.Fatalf("expected the fmt.Stringer to be formatted when added, got %d calls", calls.Load())
+		}
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Go(func() {
+				if err.Error() != "value counted" {
+					t.Error("unexpected error message")
+				}
+			})
+		}
+		wg.Wait()
+
+		err.Explainf("more %s", countingStringer{calls: &calls})
+		if err.Explanation() != "value counted, more counted" || calls.Load() != 2 {
+			t.Fatalf("expected each entry to be formatted once, got %d calls", calls.Load())
+		}
+	})
+
+	t.Run("mutated args", func(t *testing.T) {
+		t.Parallel()
+
+		ids := []int{1}
+		err := errTest.Yeetf("ids %v", ids)
+		ids[0] = 2
+
+		if err.Explanation() != "ids [2]" {
+			t.Fatalf("expected lazy formatting with the current args, got %q", err.Explanation())
+		}
+	})
+
+	t.Run("self", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTest.Yeet()
+		err.Explainf("self %v", err)
+		err.Explainf("again %s", struct{ Err oops.Error }{err})
+
+		done := make(chan string)
+		go func() {
+			done <- err.Error() + " | " + oops.ReturnTrace(err)[1].Explanation
+		}()
+
+		select {
+		case got := <-done:
+			if want := "self oops.Error, again {self oops.Error} | again {self oops.Error}"; got != want {
+				t.Fatalf("unexpected explanation\n got: %q\nwant: %q", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("formatting an explanation referencing its own error must not deadlock")
+		}
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		errSnapshot := oops.Define("code", "test.snapshot").Snapshot()
+
+		ids := []int{1}
+		err := errSnapshot.Yeetf("ids %v", ids)
+		child := errSnapshot.Child().Wrapf(err, "ids %v", ids)
+		ids[0] = 2
+
+		if err.Explanation() != "ids [1]" || child.Explanation() != "ids [1]" {
+			t.Fatalf("expected snapshot of the args, got %q and %q", err.Explanation(), child.Explanation())
+		}
+	})
+}
+
 func BenchmarkError_String(b *testing.B) {
 	b.ReportAllocs()
 
@@ -131,6 +314,51 @@ func TestError_Set(t *testing.T) {
 	}
 }
 
+func TestError_Set_copyOnWrite(t *testing.T) {
+	t.Parallel()
+
+	errDefined := oops.Define("code", "test.copy_on_write", "status", 400)
+
+	first := errDefined.Yeet().Set("status", 409).Set("id", "abc")
+	second := errDefined.Yeet()
+
+	if v, _ := first.Get("status"); v != 409 {
+		t.Fatalf("error props must shadow the definition props, got %v", v)
+	}
+
+	if v, _ := second.Get("status"); v != 400 {
+		t.Fatalf("error props must not leak to other errors, got %v", v)
+	}
+
+	if v, _ := first.Get("code"); v != "test.copy_on_write" {
+		t.Fatalf("definition props must be read through, got %v", v)
+	}
+
+	all := first.GetAll()
+	if len(all) != 3 || all["code"] != "test.copy_on_write" || all["status"] != 409 || all["id"] != "abc" {
+		t.Fatalf("unexpected merged props: %v", all)
+	}
+
+	all["status"] = 500
+	if v, _ := first.Get("status"); v != 409 {
+		t.Fatal("modifying the result of GetAll must not modify the error")
+	}
+
+	second.GetAll()["status"] = 500
+	if v, _ := errDefined.Yeet().Get("status"); v != 400 {
+		t.Fatal("modifying the result of GetAll must not modify the definition")
+	}
+
+	errDefined.Set("status", 422)
+	if v, _ := second.Get("status"); v != 400 {
+		t.Fatalf("existing errors must keep the definition props they were created with, got %v", v)
+	}
+
+	if v, _ := errDefined.Yeet().Get("status"); v != 422 {
+		t.Fatalf("new errors must read the updated definition props, got %v", v)
+	}
+}
+
 func TestError_Get_missing(t *testing.T) {
 	t.Parallel()
 
@@ -248,3 +476,14 @@ func benchmarkNested3(original error) error {
 func benchmarkNested4(original error) error {
 	return errTestBenchmark.Wrapf(original, "benchmarkNested4 returned wrapped original error")
 }
+
+func BenchmarkError_explainfUnrendered(b *testing.B) {
+	b.ReportAllocs()
+
+	for iter := 0; iter <= b.N; iter++ {
+		err := errTest.Yeetf("user %s not found in %s", "john", "users")
+		if !errors.Is(err, errTest) {
+			b.Fatal("expected errTest")
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package oops
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.sdls.io/oops/internal/unsafe"
+)
+
+var _ json.Marshaler = &errorImpl{}
+
+// errorJSON is the document produced by errorImpl.MarshalJSON and consumed by Decode. Errors that are not an Error
+// (such as a parent created with errors.New) are encoded with only Message and Parent set, which is why Props is
+// always present (even if empty) for an Error.
+type errorJSON struct {
+	Message     string         `json:"message"`
+	Explanation string         `json:"explanation,omitempty"`
+	Path        string         `json:"path,omitempty"`
+	PathArgs    []any          `json:"path_args,omitempty"`
+	Props       map[string]any `json:"props,omitzero"`
+	Trace       []string       `json:"trace,omitempty"`
+	Nested      []*errorJSON   `json:"nested,omitempty"`
+	Parent      *errorJSON     `json:"parent,omitempty"`
+}
+
+// errorJSONDecoded mirrors errorJSON, but keeps the props raw such that they can be compared against the props of the
+// resolved ErrorDefined.
+type errorJSONDecoded struct {
+	Message     string                     `json:"message"`
+	Explanation string                     `json:"explanation"`
+	Path        string                     `json:"path"`
+	PathArgs    []any                      `json:"path_args"`
+	Props       map[string]json.RawMessage `json:"props"`
+	Trace       []string                   `json:"trace"`
+	Nested      []*errorJSONDecoded        `json:"nested"`
+	Parent      *errorJSONDecoded          `json:"parent"`
+}
+
+// errorForeign is used by Decode to rebuild errors that were not an Error when encoded.
+type errorForeign struct {
+	message string
+	parent  error
+}
+
+func (err *errorForeign) Error() string {
+	return err.message
+}
+
+func (err *errorForeign) Unwrap() error {
+	return err.parent
+}
+
+// MarshalJSON encodes the error, its Error.Nested errors and its parent chain as a single document. Errors that
+// contain themselves (such as an error appended to itself) are not encoded again inside themselves.
+func (err *errorImpl) MarshalJSON() ([]byte, error) {
+	if err == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(encodeJSON(err, make(map[error]struct{})))
+}
+
+func encodeJSON(err error, seen map[error]struct{}) *errorJSON {
+	if err == nil {
+		return nil
+	}
+
+	if reflect.TypeOf(err).Comparable() {
+		if _, ok := seen[err]; ok {
+			return nil
+		}
+
+		seen[err] = struct{}{}
+		defer delete(seen, err)
+	}
+
+	v, ok := err.(Error) //nolint:errorlint
+	if !ok {
+		return &errorJSON{
+			Message: err.Error(),
+			Parent:  encodeJSON(errors.Unwrap(err), seen),
+		}
+	}
+
+	doc := &errorJSON{
+		Message:     v.Error(),
+		Explanation: v.Explanation(),
+		Path:        v.Path(),
+		PathArgs:    v.PathArgs(),
+		Props:       v.GetAll(),
+		Trace:       v.Trace(),
+		Parent:      encodeJSON(parentOf(v), seen),
+	}
+
+	if doc.Props == nil {
+		doc.Props = map[string]any{}
+	}
+
+	for _, nested := range v.Nested() {
+		if nested == nil {
+			continue
+		}
+
+		if nestedDoc := encodeJSON(nested, seen); nestedDoc != nil {
+			doc.Nested = append(doc.Nested, nestedDoc)
+		}
+	}
+
+	return doc
+}
+
+// Decode rebuilds an Error from a document produced by marshalling an Error as JSON. The Error.Source of each decoded
+// error is resolved by comparing the value of the given key prop (such as "code") against the props of the given
+// definitions. Errors whose key prop does not match any of the definitions are decoded with ErrUncaught as their
+// source, keeping all the decoded props. Parent errors that were not an Error when encoded are decoded as plain
+// errors, keeping only their message and their own parent.
+func Decode(data []byte, key string, defined ...ErrorDefined) (Error, error) { //nolint:ireturn
+	return decodeJSON(data, key, func(value string) *errorDefined {
+		for _, d := range defined {
+			vd, ok := d.(*errorDefined)
+			if !ok || vd == nil {
+				continue
+			}
+
+			v, ok := vd.props[key]
+			if ok && fmt.Sprint(v) == value {
+				return vd
+			}
+		}
+
+		return nil
+	})
+}
+
+func decodeJSON(data []byte, key string, resolve func(value string) *errorDefined) (Error, error) { //nolint:ireturn
+	var doc *errorJSONDecoded
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("oops: decode: %w", err)
+	}
+
+	if doc == nil {
+		return nil, nil
+	}
+
+	if doc.Props == nil {
+		return nil, errors.New("oops: decode: document is not an Error")
+	}
+
+	decoder := jsonDecoder{key: key, resolve: resolve}
+
+	e, err := decoder.decodeError(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+type jsonDecoder struct {
+	key     string
+	resolve func(value string) *errorDefined
+}
+
+func (decoder jsonDecoder) decode(doc *errorJSONDecoded) (error, error) {
+	if doc == nil {
+		return nil, nil
+	}
+
+	if doc.Props != nil {
+		return decoder.decodeError(doc)
+	}
+
+	parent, err := decoder.decode(doc.Parent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &errorForeign{message: doc.Message, parent: parent}, nil
+}
+
+func (decoder jsonDecoder) decodeError(doc *errorJSONDecoded) (*errorImpl, error) {
+	var defined *errorDefined
+	if raw, ok := doc.Props[decoder.key]; ok {
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("oops: decode: prop %q: %w", decoder.key, err)
+		}
+
+		defined = decoder.resolve(fmt.Sprint(value))
+	}
+
+	if defined == nil {
+		defined = ErrUncaught
+	}
+
+	parent, err := decoder.decode(doc.Parent)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &errorImpl{
+		source:   defined,
+		parent:   parent,
+		path:     doc.Path,
+		pathArgs: doc.PathArgs,
+	}
+
+	if doc.Trace != nil {
+		e.trace = unsafe.Frames(doc.Trace)
+	}
+
+	if doc.Explanation != "" {
+		e.explanations = []ExplanationEntry{{Format: doc.Explanation, text: doc.Explanation, formatted: true}}
+	}
+
+	if len(doc.Props) != 0 {
+		e.props = make(map[string]any, len(doc.Props))
+	}
+
+	for k, raw := range doc.Props {
+		// keep the defined value (and type) when the encoded value matches it
+		if v, ok := defined.props[k]; ok {
+			if encoded, err := json.Marshal(v); err == nil && string(encoded) == string(raw) {
+				e.props[k] = v
+				continue
+			}
+		}
+
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("oops: decode: prop %q: %w", k, err)
+		}
+
+		e.props[k] = value
+	}
+
+	for _, nestedDoc := range doc.Nested {
+		if nestedDoc == nil || nestedDoc.Props == nil {
+			continue
+		}
+
+		nested, err := decoder.decodeError(nestedDoc)
+		if err != nil {
+			return nil, err
+		}
+
+		e.nested = append(e.nested, nested)
+	}
+
+	return e, nil
+}
@@ -0,0 +1,242 @@
+package oops_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+func TestError_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("simple", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTestBenchmark.Yeetf("foo %s", "bar")
+		_ = err.PathSetf("user/%d", 42)
+
+		data, errMarshal := json.Marshal(err)
+		if errMarshal != nil {
+			t.Fatal(errMarshal)
+		}
+
+		want := `{"message":"foo bar","explanation":"foo bar","path":"user/42","path_args":[42],` +
+			`"props":{"code":"test.err_test_benchmark","status":418}}`
+		if string(data) != want {
+			t.Fatalf("unexpected json\n got: %s\nwant: %s", data, want)
+		}
+	})
+
+	t.Run("no props", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(oops.Define().Yeet())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != `{"message":"oops.Error","props":{}}` {
+			t.Fatalf("unexpected json: %s", data)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(oops.NilErr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != "null" {
+			t.Fatalf("unexpected json: %s", data)
+		}
+	})
+
+	t.Run("parent chain", func(t *testing.T) {
+		t.Parallel()
+
+		inner := fmt.Errorf("query: %w", errors.New("connection refused"))
+		err := errTest.Wrapf(inner, "loading user")
+
+		data, errMarshal := json.Marshal(err)
+		if errMarshal != nil {
+			t.Fatal(errMarshal)
+		}
+
+		want := `{"message":"loading user","explanation":"loading user","props":{"code":"test.err_test"},` +
+			`"parent":{"message":"query: connection refused","parent":{"message":"connection refused"}}}`
+		if string(data) != want {
+			t.Fatalf("unexpected json\n got: %s\nwant: %s", data, want)
+		}
+	})
+
+	t.Run("appended to itself", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTest.Yeet()
+		_ = err.Append(err, errTest.Yeetf("child"))
+
+		data, errMarshal := json.Marshal(err)
+		if errMarshal != nil {
+			t.Fatal(errMarshal)
+		}
+
+		want := `{"message":"oops.Error","props":{"code":"test.err_test"},` +
+			`"nested":[{"message":"child","explanation":"child","props":{"code":"test.err_test"}}]}`
+		if string(data) != want {
+			t.Fatalf("unexpected json\n got: %s\nwant: %s", data, want)
+		}
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errParent = oops.Define("code", "test.decode_parent", "status", 400)
+		errField  = oops.Define("code", "test.decode_field", "status", 422)
+	)
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		finish, addf := errParent.Collect()
+		addf(errField.Yeetf("too short"), "name")
+		addf(errField.Yeetf("not a number"), "age[%d]", 1)
+
+		original := finish()
+		original.Explainf("validating")
+		_ = original.Set("request_id", "abc")
+
+		data, err := json.Marshal(original)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := oops.Decode(data, "code", errParent, errField)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if decoded.Source() != errParent {
+			t.Fatal("decoded source must resolve to the original definition")
+		}
+
+		if !errors.Is(decoded, errParent) {
+			t.Fatal("decoded error must match the original definition")
+		}
+
+		if decoded.Explanation() != "validating" {
+			t.Fatalf("unexpected explanation: %q", decoded.Explanation())
+		}
+
+		if entries := oops.Explanations(decoded); len(entries) != 1 || entries[0].String() != "validating" {
+			t.Fatalf("decoded explanation must be kept as a single entry, got %v", entries)
+		}
+
+		if status, _ := decoded.Get("status"); status != 400 {
+			t.Fatalf("defined prop must keep its type, got %T(%v)", status, status)
+		}
+
+		if requestID, _ := decoded.Get("request_id"); requestID != "abc" {
+			t.Fatalf("unexpected request_id: %v", requestID)
+		}
+
+		nested := decoded.Nested()
+		if len(nested) != 2 {
+			t.Fatalf("expected 2 nested errors, got %d", len(nested))
+		}
+
+		if nested[1].Source() != errField || nested[1].Path() != "age[1]" {
+			t.Fatalf("unexpected nested error: %v at %q", nested[1], nested[1].Path())
+		}
+
+		if !oops.NestedIs(decoded, errField) {
+			t.Fatal("NestedIs must find the decoded nested errors")
+		}
+
+		again, err := json.Marshal(decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(again) != string(data) {
+			t.Fatalf("round trip mismatch\n got: %s\nwant: %s", again, data)
+		}
+	})
+
+	t.Run("parents", func(t *testing.T) {
+		t.Parallel()
+
+		original := errParent.Wrap(fmt.Errorf("wrapped: %w", errField.Yeetf("inner")))
+
+		data, err := json.Marshal(original)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := oops.Decode(data, "code", errParent, errField)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if decoded.Unwrap().Error() != "wrapped: inner" {
+			t.Fatalf("unexpected parent: %v", decoded.Unwrap())
+		}
+
+		inner, ok := oops.As(decoded, errField)
+		if !ok {
+			t.Fatal("expected to find the decoded inner error through the foreign parent")
+		}
+
+		if inner.Explanation() != "inner" {
+			t.Fatalf("unexpected inner explanation: %q", inner.Explanation())
+		}
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		t.Parallel()
+
+		decoded, err := oops.Decode([]byte(`{"message":"x","props":{"code":"unknown"}}`), "code", errParent)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if decoded.Source() != oops.ErrUncaught {
+			t.Fatal("unknown code must decode as ErrUncaught")
+		}
+
+		if code, _ := decoded.Get("code"); code != "unknown" {
+			t.Fatalf("decoded props must be kept, got %v", code)
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+
+		decoded, err := oops.Decode([]byte("null"), "code")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if decoded != nil {
+			t.Fatal("null must decode as nil")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := oops.Decode([]byte("{"), "code"); err == nil {
+			t.Fatal("expected invalid json to fail")
+		}
+
+		if _, err := oops.Decode([]byte(`{"message":"foreign"}`), "code"); err == nil {
+			t.Fatal("expected a document without props to fail")
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package oops
+
+import (
+	"go.sdls.io/oops/internal/unsafe"
+)
+
+// ReturnFrame is a layer an Error passed through, being a call to Explainf (the function or the Error method), or to
+// Wrap or Wrapf of an existing error. It holds the location of the call and the explanation fragment it added, if any.
+type ReturnFrame struct {
+	File        string
+	Line        int
+	Function    string
+	Explanation string
+}
+
+type errorReturn struct {
+	pc uintptr
+
+	// entry is the index of the explanation entry added by the layer, or -1 if none
+	entry int
+}
+
+// ReturnTrace returns the layers (see ReturnFrame) the error passed through, following its unwrap chain, ordered from
+// the first to the last layer (as the error bubbled up). Only layers of errors created by oops are recorded.
+func ReturnTrace(err error) []ReturnFrame {
+	var frames []ReturnFrame
+
+	for err = Normalize(err); err != nil; err = Normalize(unwrapParent(err)) {
+		v, ok := err.(*errorImpl) //nolint:errorlint
+		if !ok || len(v.returns) == 0 {
+			continue
+		}
+
+		frames = append(v.returnFrames(), frames...)
+	}
+
+	return frames
+}
+
+func (err *errorImpl) returnFrames() []ReturnFrame {
+	err.explanationMu.Lock()
+	defer err.explanationMu.Unlock()
+
+	frames := make([]ReturnFrame, len(err.returns))
+	for idx, r := range err.returns {
+		frames[idx].File, frames[idx].Line, frames[idx].Function = unsafe.CallerFrame(r.pc)
+		if r.entry >= 0 {
+			frames[idx].Explanation = err.explanationText(r.entry)
+		}
+	}
+
+	return frames
+}
@@ -0,0 +1,109 @@
+package oops_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var errTestReturns = oops.Define("code", "test.returns")
+
+func returnsOrigin() error {
+	return errTest.Yeetf("origin")
+}
+
+func returnsMiddle() error {
+	return oops.Explainf(returnsOrigin(), "middle")
+}
+
+func returnsOuter() error {
+	err := returnsMiddle()
+	if err != nil {
+		return errTestReturns.Wrapf(err, "outer %d", 1)
+	}
+
+	return nil
+}
+
+func returnsWrap() error {
+	return errTestReturns.Wrap(fmt.Errorf("foreign: %w", returnsMiddle()))
+}
+
+func TestReturnTrace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("layers", func(t *testing.T) {
+		t.Parallel()
+
+		err := returnsOuter()
+		err.(oops.Error).Explainf("caller") //nolint:errorlint,forcetypeassert
+
+		assertReturns(t, oops.ReturnTrace(err),
+			"returnsMiddle: middle", "returnsOuter: outer 1", "TestReturnTrace.func1: caller")
+
+		if frame := oops.ReturnTrace(err)[0]; !strings.HasSuffix(frame.File, "error_returns_test.go") || frame.Line != 19 {
+			t.Fatalf("unexpected location %s:%d", frame.File, frame.Line)
+		}
+	})
+
+	t.Run("wrap", func(t *testing.T) {
+		t.Parallel()
+
+		assertReturns(t, oops.ReturnTrace(returnsWrap()), "returnsMiddle: middle", "returnsWrap: ")
+	})
+
+	t.Run("foreign", func(t *testing.T) {
+		t.Parallel()
+
+		err := oops.Explainf(errors.New("foreign"), "explained")
+		assertReturns(t, oops.ReturnTrace(err), "TestReturnTrace.func3: explained")
+
+		if trace := err.Trace(); len(trace) == 0 || !strings.HasSuffix(trace[0], ": TestReturnTrace.func3") {
+			t.Fatalf("expected trace to start at the caller, got %v", trace)
+		}
+	})
+
+	t.Run("unwrap nested", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTestUnwrapCollected.Wrapf(returnsMiddle(), "outer").Append(errTest.Yeet())
+		assertReturns(t, oops.ReturnTrace(err), "returnsMiddle: middle", "TestReturnTrace.func4: outer")
+	})
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+
+		if frames := oops.ReturnTrace(errTest.Wrapf(nil, "created")); len(frames) != 0 {
+			t.Fatalf("expected no layers for new errors, got %v", frames)
+		}
+
+		if frames := oops.ReturnTrace(errors.New("foreign")); len(frames) != 0 {
+			t.Fatalf("expected no layers for foreign errors, got %v", frames)
+		}
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		t.Parallel()
+
+		tree := fmt.Sprintf("%+v", returnsOuter())
+		if !strings.Contains(tree, "\treturns:\n") || !strings.Contains(tree, "(returnsOuter): outer 1\n") {
+			t.Fatalf("expected return trace in tree, got:\n%s", tree)
+		}
+	})
+}
+
+func assertReturns(t *testing.T, frames []oops.ReturnFrame, want ...string) {
+	t.Helper()
+
+	got := make([]string, len(frames))
+	for idx, frame := range frames {
+		got[idx] = frame.Function + ": " + frame.Explanation
+	}
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected return trace\n got: %q\nwant: %q", got, want)
+	}
+}
@@ -0,0 +1,136 @@
+package oops
+
+import (
+	"errors"
+	"log/slog"
+)
+
+var _ slog.LogValuer = &errorImpl{}
+
+// LogValue implements slog.LogValuer, see the package level LogValue.
+func (err *errorImpl) LogValue() slog.Value {
+	if err == nil {
+		return slog.StringValue(err.Error())
+	}
+
+	return logValue(err, err, make(map[Error]struct{}))
+}
+
+// Attr returns the given error as a slog.Attr with the "error" key, see LogValue.
+func Attr(err error) slog.Attr {
+	return slog.Attr{Key: "error", Value: LogValue(err)}
+}
+
+// LogValue returns the given error as a slog.Value. If the error is an Error, or if an Error is found in its unwrap
+// chain, the value is a group with the message (the output of err.Error), the code (as identified by the
+// DefaultRegistry key prop of the Error.Source), the path, the props, the Error.Nested errors as a list and the trace,
+// if the Error.Source was defined with tracing enabled. Otherwise, the value is just the message. Typed nil errors (see
+// Normalize) are logged as nil.
+func LogValue(err error) slog.Value {
+	err = Normalize(err)
+	if err == nil {
+		return slog.AnyValue(nil)
+	}
+
+	var v Error
+	if !errors.As(err, &v) || isNilError(v) {
+		return slog.StringValue(err.Error())
+	}
+
+	return logValue(err, v, make(map[Error]struct{}))
+}
+
+func logValue(err error, v Error, seen map[Error]struct{}) slog.Value {
+	seen[v] = struct{}{}
+	defer delete(seen, v)
+
+	attrs := make([]slog.Attr, 0, 6)
+	attrs = append(attrs, slog.String("message", err.Error()))
+
+	if code, ok := logCode(v); ok {
+		attrs = append(attrs, slog.Any("code", code))
+	}
+
+	if path := v.Path(); path != "" {
+		attrs = append(attrs, slog.String("path", path))
+	}
+
+	if props := v.GetAll(); len(props) != 0 {
+		propAttrs := make([]slog.Attr, 0, len(props))
+		for _, key := range sortedKeys(props) {
+			propAttrs = append(propAttrs, slog.Any(key, props[key]))
+		}
+
+		attrs = append(attrs, slog.Attr{Key: "props", Value: slog.GroupValue(propAttrs...)})
+	}
+
+	if nested := logNested(v, seen); len(nested) != 0 {
+		attrs = append(attrs, slog.Any("nested", nested))
+	}
+
+	if logTraced(v) {
+		attrs = append(attrs, slog.Any("trace", v.Trace()))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// logNested returns the nested errors as maps, such that handlers are able to render them as a list.
+func logNested(v Error, seen map[Error]struct{}) []any {
+	nested := v.Nested()
+	if len(nested) == 0 {
+		return nil
+	}
+
+	list := make([]any, 0, len(nested))
+	for _, n := range nested {
+		if isNilError(n) {
+			continue
+		}
+
+		if _, ok := seen[n]; ok {
+			continue
+		}
+
+		attrs := logValue(n, n, seen).Group()
+		m := make(map[string]any, len(attrs))
+		for _, attr := range attrs {
+			if attr.Value.Kind() == slog.KindGroup {
+				group := attr.Value.Group()
+				props := make(map[string]any, len(group))
+				for _, prop := range group {
+					props[prop.Key] = prop.Value.Any()
+				}
+
+				m[attr.Key] = props
+				continue
+			}
+
+			m[attr.Key] = attr.Value.Any()
+		}
+
+		list = append(list, m)
+	}
+
+	return list
+}
+
+func logCode(v Error) (any, bool) {
+	vd, ok := v.Source().(*errorDefined)
+	if !ok || vd == nil {
+		return nil, false
+	}
+
+	code, ok := vd.props[DefaultRegistry.Key()]
+
+	return code, ok
+}
+
+func logTraced(v Error) bool {
+	vd, ok := v.Source().(*errorDefined)
+	if !ok || vd == nil {
+		return len(v.Trace()) != 0
+	}
+
+	return vd.traced
+}
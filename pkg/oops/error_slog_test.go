@@ -0,0 +1,145 @@
+package oops_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+func slogJSON(t *testing.T, args ...any) map[string]any {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && (attr.Key == slog.TimeKey || attr.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	}))
+	logger.Info("test", args...)
+
+	var m map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("invalid log line %q: %v", buf.String(), err)
+	}
+
+	return m
+}
+
+func TestError_LogValue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("grouped", func(t *testing.T) {
+		t.Parallel()
+
+		finish, addf := errTestBenchmark.Collect()
+		addf(errTest.Yeetf("too short"), "name")
+
+		err := finish()
+		err.Explainf("validating")
+		_ = err.PathSetf("users/%d", 1)
+
+		m := slogJSON(t, "err", err)
+
+		got, _ := json.Marshal(m["err"])
+		want := `{"code":"test.err_test_benchmark","message":"validating",` +
+			`"nested":[{"code":"test.err_test","message":"too short","path":"name","props":{"code":"test.err_test"}}],` +
+			`"path":"users/1","props":{"code":"test.err_test_benchmark","status":418}}`
+
+		if string(got) != want {
+			t.Fatalf("unexpected log value\n got: %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("trace", func(t *testing.T) {
+		t.Parallel()
+
+		m := slogJSON(t, "err", errTestTrace.Yeet())
+
+		group, _ := m["err"].(map[string]any)
+		if trace, _ := group["trace"].([]any); len(trace) == 0 {
+			t.Fatalf("expected trace for traced definition, got %v", group)
+		}
+
+		m = slogJSON(t, "err", errTest.Yeet())
+
+		group, _ = m["err"].(map[string]any)
+		if _, ok := group["trace"]; ok {
+			t.Fatalf("expected no trace for untraced definition, got %v", group)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		m := slogJSON(t, "err", oops.NilErr)
+		if m["err"] != "oops.Error(nil)" {
+			t.Fatalf("unexpected log value: %v", m["err"])
+		}
+	})
+}
+
+func TestAttr(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("handler: %w", errTest.Yeetf("not found"))
+
+		m := slogJSON(t, oops.Attr(err))
+
+		got, _ := json.Marshal(m["error"])
+		want := `{"code":"test.err_test","message":"handler: not found","props":{"code":"test.err_test"}}`
+
+		if string(got) != want {
+			t.Fatalf("unexpected log value\n got: %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		t.Parallel()
+
+		m := slogJSON(t, oops.Attr(errors.New("plain")))
+		if m["error"] != "plain" {
+			t.Fatalf("unexpected log value: %v", m["error"])
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		m := slogJSON(t, oops.Attr(nil))
+		if v, ok := m["error"]; !ok || v != nil {
+			t.Fatalf("unexpected log value: %v", v)
+		}
+	})
+	t.Run("typed nil", func(t *testing.T) {
+		t.Parallel()
+
+		if m := slogJSON(t, oops.Attr(oops.NilErr)); m["error"] != nil {
+			t.Fatalf("unexpected log value: %v", m["error"])
+		}
+
+		if m := slogJSON(t, slog.Any("err", oops.NilErr)); m["err"] != "oops.Error(nil)" {
+			t.Fatalf("unexpected log value: %v", m["err"])
+		}
+
+		if m := slogJSON(t, oops.Attr(fmt.Errorf("handler: %w", oops.NilErr))); m["error"] != "handler: oops.Error(nil)" {
+			t.Fatalf("unexpected log value: %v", m["error"])
+		}
+
+		m := slogJSON(t, oops.Attr(errTest.Yeet().Append(oops.NilErr)))
+		if group, ok := m["error"].(map[string]any); !ok || group["nested"] != nil {
+			t.Fatalf("unexpected log value: %v", m["error"])
+		}
+	})
+}
@@ -1,20 +1,33 @@
 package oops
 
+import (
+	"reflect"
+
+	"go.sdls.io/oops/internal/unsafe"
+)
+
 // Explainf is a helper function to check the given error if it's an Error and then call Error.Explainf with the given
-// format and arguments, if and only if it's also not nil. If the given error is not an Error, it will be wrapped with
-// ErrUncaught and the format and arguments will be passed to it.
+// format and arguments, if and only if it's also not nil (including typed nil errors, see Normalize). If the given
+// error is not an Error, it will be wrapped with ErrUncaught and the format and arguments will be passed to it.
 func Explainf(err error, format string, args ...any) Error { //nolint:ireturn
+	err = Normalize(err)
 	if err == nil {
 		return nil
 	}
 
 	v, ok := err.(Error) //nolint:errorlint
 	if !ok {
-		return ErrUncaught.Wrapf(err, format, args...)
+		uncaught := ErrUncaught.newErrorUntraced(err)
+		uncaught.trace = unsafe.Capture(2)
+		ErrUncaught.runHooks(OnWrap, uncaught)
+		uncaught.explain(unsafe.Caller(2), format, args...)
+
+		return uncaught
 	}
 
-	if v == nil {
-		return nil
+	if vi, ok := v.(*errorImpl); ok {
+		vi.explain(unsafe.Caller(2), format, args...)
+		return vi
 	}
 
 	v.Explainf(format, args...)
@@ -22,64 +35,49 @@ func Explainf(err error, format string, args ...any) Error { //nolint:ireturn
 	return v
 }
 
-// As will check if the given err is an Error and if the Error.Source matches the target ErrorDefined, at which point
-// err gets returned as an Error. If the given err is not an Error, or if the Error.Source does not match, the check
-// is repeated with the parent of err (if any) until either the check is successful, or the parent is nil.
+// As will check if the given err is an Error and if the Error.Source matches (or descends from) the target
+// ErrorDefined, at which point err gets returned as an Error. If the given err is not an Error, or if the Error.Source
+// does not match, the check is repeated with the parent of err (if any, or with each of the errors of errors.Join and
+// any Unwrap() []error) until either the check is successful, or the parents exhaust (see Walk). Typed nil errors (see
+// Normalize) never match.
 // As does not check Error.Nested errors.
 func As(err error, target ErrorDefined) (Error, bool) { //nolint:ireturn
-	if err == nil {
-		return nil, false
-	}
-
-	v, ok := err.(Error) //nolint:errorlint
-	if !ok {
-		return asErr(err, target)
-	}
-
-	if v == nil {
-		return nil, false
-	}
+	// follow the unwrap chain without allocating, until it branches into multiple errors
+	for err = Normalize(err); err != nil; err = Normalize(err) {
+		switch vv := err.(type) { //nolint:errorlint
+		case Error:
+			if sourceIs(vv.Source(), target) {
+				return vv, true
+			}
 
-	if v.Source() == target {
-		return v, true
+			err = parentOf(vv)
+		case interface{ Unwrap() error }:
+			err = vv.Unwrap()
+		case interface{ Unwrap() []error }, interface{ Unwraps() []error }:
+			return asBranches(err, target)
+		default:
+			return nil, false
+		}
 	}
 
-	return As(v.Unwrap(), target)
+	return nil, false
 }
 
-func asErr(err error, target ErrorDefined) (Error, bool) { //nolint:ireturn
-	switch vv := err.(type) { //nolint:errorlint
-	case interface{ Unwrap() error }:
-		return As(vv.Unwrap(), target)
-	case interface{ Unwrap() []error }:
-		for _, er := range vv.Unwrap() {
-			if er == nil {
-				continue
-			}
+// asBranches is As for errors unwrapping into multiple errors, checking each of them (see Walk).
+func asBranches(err error, target ErrorDefined) (Error, bool) { //nolint:ireturn
+	var found Error
 
-			aer, ok := As(er, target)
-			if ok {
-				return aer, true
-			}
+	walk(err, walkParents, func(err error, _ int, _ []string) bool {
+		v, ok := err.(Error) //nolint:errorlint
+		if ok && sourceIs(v.Source(), target) {
+			found = v
+			return false
 		}
 
-		return nil, false
-	case interface{ Unwraps() []error }:
-		for _, er := range vv.Unwraps() {
-			if er == nil {
-				continue
-			}
-
-			aer, ok := As(er, target)
-			if ok {
-				return aer, true
-			}
-		}
+		return true
+	}, nil)
 
-		return nil, false
-	}
-
-	return nil, false
+	return found, found != nil
 }
 
 // AssertAny will check if the given err is an Error and if so, return it as an Error. AssertAny does not check the
@@ -94,8 +92,9 @@ func AssertAny(err error) (Error, bool) { //nolint:ireturn
 }
 
 // MustAny will cast the given error as an Error, if the error does not implement Error, then it will become
-// ErrUncaught. MustAny does not check the unwrap chain.
+// ErrUncaught. Typed nil errors (see Normalize) return nil. MustAny does not check the unwrap chain.
 func MustAny(err error) Error { //nolint:ireturn
+	err = Normalize(err)
 	if err == nil {
 		return nil
 	}
@@ -107,3 +106,32 @@ func MustAny(err error) Error { //nolint:ireturn
 
 	return v
 }
+
+// Normalize returns nil if the given error is nil, or if it's an Error holding a nil pointer (such as NilErr), which
+// would otherwise be a non-nil error (as an interface holding a typed nil). Any other error is returned as is.
+func Normalize(err error) error {
+	if isNilError(err) {
+		return nil
+	}
+
+	return err
+}
+
+func isNilError(err error) bool {
+	switch v := err.(type) { //nolint:errorlint
+	case nil:
+		return true
+	case *errorImpl:
+		return v == nil
+	case Error:
+		value := reflect.ValueOf(v)
+		switch value.Kind() { //nolint:exhaustive
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+			return value.IsNil()
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
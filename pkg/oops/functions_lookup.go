@@ -0,0 +1,79 @@
+package oops
+
+// LookupOption changes which errors Lookup and LookupAll search, and in which order.
+type LookupOption uint8
+
+const (
+	// LookupNested makes Lookup and LookupAll also search the Error.Nested errors (after the parent of each Error).
+	LookupNested LookupOption = 1 << iota
+
+	// LookupInnermost makes the innermost errors win, such that the props of a wrapped error take precedence over the
+	// props of the errors wrapping it. By default, the outermost errors win (as with Error.Get, where the props set on
+	// an error shadow the props of its definition).
+	LookupInnermost
+)
+
+// Lookup returns the value of the prop of the first Error holding it (see Error.Get), searching the given error, its
+// unwrap chain (including the errors of errors.Join and any Unwrap() []error), and the Error.Nested errors if
+// LookupNested is given. The outermost errors are searched first, unless LookupInnermost is given.
+func Lookup(err error, key string, options ...LookupOption) (any, bool) {
+	var (
+		value any
+		found bool
+	)
+
+	lookup(err, key, lookupOptions(options), func(v any) bool {
+		value, found = v, true
+		return false
+	})
+
+	return value, found
+}
+
+// LookupAll returns the values of the prop of all the Error values holding it, searched as with Lookup, in order of
+// precedence.
+func LookupAll(err error, key string, options ...LookupOption) []any {
+	var values []any
+
+	lookup(err, key, lookupOptions(options), func(v any) bool {
+		values = append(values, v)
+		return true
+	})
+
+	return values
+}
+
+func lookupOptions(options []LookupOption) LookupOption {
+	var merged LookupOption
+	for _, option := range options {
+		merged |= option
+	}
+
+	return merged
+}
+
+// lookup calls yield with the values of the prop, until yield returns false. The errors are visited as with Walk, each
+// Error before (or, with LookupInnermost, after) its parent and nested errors.
+func lookup(err error, key string, options LookupOption, yield func(value any) bool) {
+	visit := func(err error, _ int, _ []string) bool {
+		v, ok := err.(Error) //nolint:errorlint
+		if !ok {
+			return true
+		}
+
+		value, found := v.Get(key)
+
+		return !found || yield(value)
+	}
+
+	mode := walkParents
+	if options&LookupNested != 0 {
+		mode = walkAll
+	}
+
+	if options&LookupInnermost != 0 {
+		walk(err, mode, nil, visit)
+	} else {
+		walk(err, mode, visit, nil)
+	}
+}
@@ -0,0 +1,111 @@
+package oops_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var (
+	errTestLookupRepo    = oops.Define("code", "test.lookup.repo", "status", 404, "retryable", true)
+	errTestLookupService = oops.Define("code", "test.lookup.service", "status", 500)
+	errTestLookupField   = oops.Define("code", "test.lookup.field", "field", "name")
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("chain", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("handler: %w", errTestLookupService.Wrap(errTestLookupRepo.Yeet()))
+
+		if value, ok := oops.Lookup(err, "status"); !ok || value != 500 {
+			t.Fatalf("expected outermost 500, got %v (%t)", value, ok)
+		}
+
+		if value, ok := oops.Lookup(err, "status", oops.LookupInnermost); !ok || value != 404 {
+			t.Fatalf("expected innermost 404, got %v (%t)", value, ok)
+		}
+
+		if value, ok := oops.Lookup(err, "retryable"); !ok || value != true {
+			t.Fatalf("expected retryable from the wrapped error, got %v (%t)", value, ok)
+		}
+
+		if _, ok := oops.Lookup(err, "missing"); ok {
+			t.Fatal("expected missing prop not to be found")
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTestLookupService.Wrap(errTestLookupRepo.Yeet())
+
+		if values := oops.LookupAll(err, "status"); !reflect.DeepEqual(values, []any{500, 404}) {
+			t.Fatalf("unexpected values %v", values)
+		}
+
+		if values := oops.LookupAll(err, "status", oops.LookupInnermost); !reflect.DeepEqual(values, []any{404, 500}) {
+			t.Fatalf("unexpected innermost values %v", values)
+		}
+	})
+
+	t.Run("join", func(t *testing.T) {
+		t.Parallel()
+
+		err := errors.Join(errors.New("foreign"), errTestLookupRepo.Yeet())
+		if value, ok := oops.Lookup(err, "status"); !ok || value != 404 {
+			t.Fatalf("expected 404 from the joined error, got %v (%t)", value, ok)
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTestLookupService.Yeet().Append(errTestLookupField.Yeet())
+
+		if _, ok := oops.Lookup(err, "field"); ok {
+			t.Fatal("expected nested errors not to be searched by default")
+		}
+
+		if value, ok := oops.Lookup(err, "field", oops.LookupNested); !ok || value != "name" {
+			t.Fatalf("expected nested field, got %v (%t)", value, ok)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTestLookupService.Yeet()
+		err.Append(err) //nolint:oopsvet // errorImpl appends to the receiver
+
+		if values := oops.LookupAll(err, "status", oops.LookupNested); !reflect.DeepEqual(values, []any{500}) {
+			t.Fatalf("unexpected values %v", values)
+		}
+	})
+
+	t.Run("typed", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTestLookupService.Wrap(errTestKeys.Yeet())
+		if status, ok := keyTestStatus.Lookup(err); !ok || status != 404 {
+			t.Fatalf("expected 404, got %d (%t)", status, ok)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		if _, ok := oops.Lookup(nil, "status"); ok {
+			t.Fatal("expected nothing for nil")
+		}
+
+		if values := oops.LookupAll(oops.NilErr, "status"); len(values) != 0 {
+			t.Fatalf("expected nothing for typed nil, got %v", values)
+		}
+	})
+}
@@ -1,7 +1,5 @@
 package oops
 
-import "errors"
-
 // Nest is a shortcut to ErrorDefined.Yeet followed by a call to Error.Append, if and only if the source is not nil and
 // the given errors are not empty.
 func Nest(source ErrorDefined, nested ...Error) Error { //nolint:ireturn
@@ -12,68 +10,64 @@ func Nest(source ErrorDefined, nested ...Error) Error { //nolint:ireturn
 	return source.Yeet().Append(nested...)
 }
 
-// NestedAs will check if the given err is an Error and if the Error.Source matches the target ErrorDefined, at which
-// point err gets returned as an Error. If the given err is not an Error, it will attempt to traverse the unwrap chain
-// until an Error is found or nil is reached. Once an Error is found, the check is repeated strictly on Error.Nested
-// errors and never up to the parent of any errors. If any of the nested errors' source matches the target, the
-// nested error is returned. The check is repeated recursively until either the check is successful, or the nested
-// errors exhaust.
+// NestedAs will check if the given err is an Error and if the Error.Source matches (or descends from) the target
+// ErrorDefined, at which point err gets returned as an Error. If the given err is not an Error, it will attempt to
+// traverse the unwrap chain until an Error is found or nil is reached (see Chain). Once an Error is found, the check is
+// repeated strictly on Error.Nested errors and never up to the parent of any errors. If any of the nested errors'
+// source matches the target, the nested error is returned. The check is repeated recursively (see Walk) until either
+// the check is successful, or the nested errors exhaust.
 func NestedAs(err error, target ErrorDefined) (Error, bool) { //nolint:ireturn
-	if err == nil {
+	v := nestedRoot(err)
+	if v == nil {
 		return nil, false
 	}
 
-	v, ok := err.(Error) //nolint:errorlint
-	if !ok {
-		return NestedAs(errors.Unwrap(err), target)
+	if sourceIs(v.Source(), target) {
+		return v, true
 	}
 
-	if v == nil {
+	if len(v.Nested()) == 0 {
 		return nil, false
 	}
 
-	if v.Source() == target {
-		return v, true
-	}
+	var found Error
 
-	for _, nested := range v.Nested() {
-		if result, ok := NestedAs(nested, target); ok {
-			return result, true
+	walk(v, walkNested, func(err error, _ int, _ []string) bool {
+		n, ok := err.(Error) //nolint:errorlint
+		if ok && sourceIs(n.Source(), target) {
+			found = n
+			return false
 		}
-	}
 
-	return nil, false
+		return true
+	}, nil)
+
+	return found, found != nil
 }
 
-// NestedIs will check if the given err is an Error and if the Error.Source matches the target ErrorDefined. If the
-// given error is not an Error, it will attempt to traverse the unwrap chain until an Error is found or nil is reached.
-// Once an Error is found, the check is repeated strictly on Error.Nested errors and never up to the parent of any
-// errors. If any of the nested errors' source matches the target, true is returned. The check is repeated recursively
-// until  either the check is successful, or the nested errors exhaust.
+// NestedIs will check if the given err is an Error and if the Error.Source matches (or descends from) the target
+// ErrorDefined. If the given error is not an Error, it will attempt to traverse the unwrap chain until an Error is
+// found or nil is reached. Once an Error is found, the check is repeated strictly on Error.Nested errors and never up
+// to the parent of any errors. If any of the nested errors' source matches the target, true is returned. The check is
+// repeated recursively until  either the check is successful, or the nested errors exhaust.
 // This function respects nil as valid targets (compared to NestedAs which does not).
 func NestedIs(err error, target ErrorDefined) bool {
-	if err == nil {
+	if nestedRoot(err) == nil {
 		return target == nil
 	}
 
-	v, ok := err.(Error) //nolint:errorlint
-	if !ok {
-		return NestedIs(errors.Unwrap(err), target)
-	}
-
-	if v == nil {
-		return target == nil
-	}
+	_, ok := NestedAs(err, target)
 
-	if v.Source() == target {
-		return true
-	}
+	return ok
+}
 
-	for _, nested := range v.Nested() {
-		if NestedIs(nested, target) {
-			return true
+// nestedRoot returns the first Error of the unwrap chain of the given error (see Chain), or nil if there is none.
+func nestedRoot(err error) Error { //nolint:ireturn
+	for err = Normalize(err); err != nil; err = Normalize(unwrapParent(err)) {
+		if v, ok := err.(Error); ok { //nolint:errorlint
+			return v
 		}
 	}
 
-	return false
+	return nil
 }
@@ -91,6 +91,14 @@ func TestExplain(t *testing.T) {
 		}
 	})
 
+	t.Run("explain typed nil", func(t *testing.T) {
+		t.Parallel()
+
+		if err := oops.Explainf(oops.NilErr, "foo bar baz"); err != nil {
+			t.Fatal("explain must not create error from typed nil")
+		}
+	})
+
 	t.Run("explain new error", func(t *testing.T) {
 		t.Parallel()
 
@@ -145,6 +153,18 @@ func TestAs_minimal(t *testing.T) {
 	t.Log(oerr)
 }
 
+func TestAs_typedNil(t *testing.T) {
+	t.Parallel()
+
+	if v, ok := oops.As(fmt.Errorf("wrap: %w", oops.NilErr), errTest); ok || v != nil {
+		t.Fatal("typed nil error cannot be errTest")
+	}
+
+	if oops.NestedIs(oops.NilErr, errTest) || !oops.NestedIs(oops.NilErr, nil) {
+		t.Fatal("typed nil error must be treated as nil by NestedIs")
+	}
+}
+
 func TestAs_fmtErrorfWrap(t *testing.T) {
 	t.Parallel()
 
@@ -251,8 +271,51 @@ func TestMustAny(t *testing.T) {
 			t.Fatal("MustAny must return nil for nil input")
 		}
 	})
+
+	t.Run("typed nil", func(t *testing.T) {
+		t.Parallel()
+
+		if got := oops.MustAny(oops.NilErr); got != nil {
+			t.Fatal("MustAny must return nil for typed nil input")
+		}
+	})
 }
 
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	if oops.Normalize(nil) != nil {
+		t.Fatal("Normalize(nil) must return nil")
+	}
+
+	if oops.Normalize(oops.NilErr) != nil {
+		t.Fatal("Normalize(NilErr) must return nil")
+	}
+
+	typedNil := func() error {
+		var err oops.Error = oops.NilErr
+		return err
+	}
+
+	if typedNil() == nil || oops.Normalize(typedNil()) != nil {
+		t.Fatal("Normalize must return nil for an Error holding a nil pointer")
+	}
+
+	err := errTest.Yeet()
+	if oops.Normalize(err) != err {
+		t.Fatal("Normalize must return non-nil errors as is")
+	}
+
+	var foreign *testForeignError
+	if oops.Normalize(foreign) == nil {
+		t.Fatal("Normalize must not change errors that are not an Error")
+	}
+}
+
+type testForeignError struct{}
+
+func (*testForeignError) Error() string { return "foreign" }
+
 func TestNest(t *testing.T) {
 	t.Parallel()
 
@@ -368,3 +431,43 @@ func TestNestedIs(t *testing.T) {
 		}
 	})
 }
+
+// TestAs_allocations is not parallel, as required by testing.AllocsPerRun.
+func TestAs_allocations(t *testing.T) {
+	err := fmt.Errorf("outer: %w", errTestTrace.Wrap(fmt.Errorf("inner: %w", errTest.Yeet())))
+
+	if allocs := testing.AllocsPerRun(100, func() {
+		if _, ok := oops.As(err, errTest); !ok {
+			t.Fatal("expected to find the error")
+		}
+	}); allocs != 0 {
+		t.Fatalf("expected As not to allocate along an unwrap chain, got %v allocations", allocs)
+	}
+}
+
+func TestNestedAs_cycle(t *testing.T) {
+	t.Parallel()
+
+	err := errTestExplainNested.Yeet()
+	err.Append(err, errTest.Yeet()) //nolint:oopsvet // errorImpl appends to the receiver
+
+	if v, ok := oops.NestedAs(err, errTest); !ok || v.Source() != errTest {
+		t.Fatal("expected to find the nested error")
+	}
+
+	if oops.NestedIs(err, errTestTrace) {
+		t.Fatal("expected not to find the definition")
+	}
+}
+
+func BenchmarkAs(b *testing.B) {
+	b.ReportAllocs()
+
+	err := fmt.Errorf("outer: %w", errTestTrace.Wrap(fmt.Errorf("inner: %w", errTest.Yeet())))
+
+	for b.Loop() {
+		if _, ok := oops.As(err, errTest); !ok {
+			b.Fatal("expected to find the error")
+		}
+	}
+}
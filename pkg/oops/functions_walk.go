@@ -0,0 +1,133 @@
+package oops
+
+import (
+	"iter"
+	"reflect"
+)
+
+// WalkFunc is called by Walk for each error of the tree, with its depth (0 being the given error) and the non-empty
+// Error.Path values from the given error down to (and including) it. The path is reused across calls, copy it to keep
+// it. Returning false stops the walk.
+type WalkFunc = func(err error, depth int, path []string) bool
+
+// Walk calls fn for each error of the tree of the given error, depth-first: each error before its parents (or the
+// errors of errors.Join and any Unwrap() []error), followed by its Error.Nested errors. An Error is only visited once,
+// even if it's part of the tree more than once (such as when appended to itself). Typed nil errors (see Normalize)
+// are skipped.
+func Walk(err error, fn WalkFunc) {
+	walk(err, walkAll, fn, nil)
+}
+
+// All returns an iterator over the errors of the tree of the given error, in the order of Walk.
+func All(err error) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		walk(err, walkAll, func(err error, _ int, _ []string) bool {
+			return yield(err)
+		}, nil)
+	}
+}
+
+// Chain returns an iterator over the unwrap chain of the given error: the error itself, followed by its parent (see
+// errors.Unwrap, but never the nested errors exposed by the UnwrapNested builder or SetUnwrapNested) and so on. Errors
+// unwrapping into multiple errors (such as the errors of errors.Join) end the chain, use All to visit them. The chain
+// ends if an Error is reached again.
+func Chain(err error) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		visited := make(map[Error]struct{})
+
+		for err = Normalize(err); err != nil; err = Normalize(unwrapParent(err)) {
+			if !walkVisit(visited, err) || !yield(err) {
+				return
+			}
+		}
+	}
+}
+
+// walkMode selects the children of each error visited by walk.
+type walkMode uint8
+
+const (
+	// walkParents visits the parent of each error (or the errors of errors.Join and any Unwrap() []error)
+	walkParents walkMode = 1 << iota
+
+	// walkNested visits the Error.Nested errors of each Error
+	walkNested
+
+	walkAll = walkParents | walkNested
+)
+
+// walk visits the tree of the given error depth-first, calling pre before (and post after) the children (selected by
+// the mode) of each error, until either returns false, which walk then returns.
+func walk(err error, mode walkMode, pre, post WalkFunc) bool {
+	visited := make(map[Error]struct{})
+
+	var visit func(err error, depth int, path []string) bool
+	visit = func(err error, depth int, path []string) bool {
+		err = Normalize(err)
+		if err == nil || !walkVisit(visited, err) {
+			return true
+		}
+
+		if v, ok := err.(Error); ok && v.Path() != "" { //nolint:errorlint
+			path = append(path, v.Path())
+		}
+
+		if pre != nil && !pre(err, depth, path) {
+			return false
+		}
+
+		for _, child := range walkChildren(err, mode) {
+			if !visit(child, depth+1, path) {
+				return false
+			}
+		}
+
+		return post == nil || post(err, depth, path)
+	}
+
+	return visit(err, 0, nil)
+}
+
+// walkVisit records the given error as visited, returning false if it already was. Only Error values are recorded,
+// being the only ones which can be part of a cycle (see Error.Append).
+func walkVisit(visited map[Error]struct{}, err error) bool {
+	v, ok := err.(Error) //nolint:errorlint
+	if !ok || !reflect.TypeOf(v).Comparable() {
+		return true
+	}
+
+	if _, seen := visited[v]; seen {
+		return false
+	}
+
+	visited[v] = struct{}{}
+
+	return true
+}
+
+// walkChildren returns the unwrapped errors of the given error, followed by its Error.Nested errors, as selected by the
+// mode.
+func walkChildren(err error, mode walkMode) []error {
+	var children []error
+
+	if mode&walkParents != 0 {
+		switch vv := err.(type) { //nolint:errorlint
+		case Error:
+			children = append(children, parentOf(vv))
+		case interface{ Unwrap() error }:
+			children = append(children, vv.Unwrap())
+		case interface{ Unwrap() []error }:
+			children = append(children, vv.Unwrap()...)
+		case interface{ Unwraps() []error }:
+			children = append(children, vv.Unwraps()...)
+		}
+	}
+
+	if v, ok := err.(Error); ok && mode&walkNested != 0 { //nolint:errorlint
+		for _, child := range v.Nested() {
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
@@ -0,0 +1,112 @@
+package oops_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var (
+	errTestWalkRoot  = oops.Define("code", "test.walk.root")
+	errTestWalkField = oops.Define("code", "test.walk.field")
+)
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	t.Run("tree", func(t *testing.T) {
+		t.Parallel()
+
+		foreign := errors.New("foreign")
+		err := errTestWalkRoot.Wrap(errors.Join(foreign, errTest.Yeet())).Append(
+			errTestWalkField.Yeet().PathSetf("user").Append(errTestWalkField.Yeet().PathSetf("name")),
+		)
+
+		var got []string
+
+		oops.Walk(err, func(err error, depth int, path []string) bool {
+			var code any = "-"
+			if v, ok := err.(oops.Error); ok { //nolint:errorlint
+				code, _ = v.Get("code")
+			}
+
+			got = append(got, fmt.Sprintf("%d %v %s", depth, code, strings.Join(path, ".")))
+
+			return true
+		})
+
+		want := []string{
+			"0 test.walk.root ",
+			"1 - ",
+			"2 - ",
+			"2 test.err_test ",
+			"1 test.walk.field user",
+			"2 test.walk.field user.name",
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("unexpected walk\n got: %q\nwant: %q", got, want)
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		t.Parallel()
+
+		count := 0
+		oops.Walk(errTestWalkRoot.Wrap(errTest.Yeet()), func(error, int, []string) bool {
+			count++
+			return false
+		})
+
+		if count != 1 {
+			t.Fatalf("expected the walk to stop, visited %d", count)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+
+		err := errTestWalkRoot.Yeet()
+		err.Append(err, errTestWalkField.Yeet()) //nolint:oopsvet // errorImpl appends to the receiver
+
+		if count := len(slices.Collect(oops.All(err))); count != 2 {
+			t.Fatalf("expected 2 errors, got %d", count)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		if count := len(slices.Collect(oops.All(oops.NilErr))); count != 0 {
+			t.Fatalf("expected no errors, got %d", count)
+		}
+	})
+}
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	inner := errTest.Yeet()
+	err := fmt.Errorf("outer: %w", errTestWalkRoot.Wrap(inner).Append(errTestWalkField.Yeet()))
+
+	chain := slices.Collect(oops.Chain(err))
+	if len(chain) != 3 || chain[0] != err || chain[2] != inner {
+		t.Fatalf("unexpected chain %v", chain)
+	}
+
+	for range oops.Chain(err) {
+		break
+	}
+
+	nested := errTestUnwrapCollected.Wrap(inner).Append(errTestWalkField.Yeet())
+	if chain := slices.Collect(oops.Chain(nested)); len(chain) != 2 || chain[1] != inner {
+		t.Fatalf("expected the chain to skip the nested errors exposed by UnwrapNested, got %v", chain)
+	}
+
+	if count := len(slices.Collect(oops.Chain(errors.Join(inner, inner)))); count != 1 {
+		t.Fatalf("expected joins to end the chain, got %d errors", count)
+	}
+}
@@ -0,0 +1,123 @@
+package oops
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// HookEvent is the way an Error was created, as given to a Hook.
+type HookEvent uint8
+
+const (
+	// OnYeet is the event of the errors created by ErrorDefined.Yeet and ErrorDefined.Yeetf (and Wrap or Wrapf given a
+	// nil error).
+	OnYeet HookEvent = iota + 1
+
+	// OnWrap is the event of the errors created by ErrorDefined.Wrap and ErrorDefined.Wrapf, wrapping a non-nil error.
+	OnWrap
+
+	// OnCollect is the event of the errors created by the collectors (such as ErrorDefined.Collect) when finished,
+	// before the collected errors are nested.
+	OnCollect
+)
+
+// String returns the name of the event, such as "yeet".
+func (event HookEvent) String() string {
+	switch event {
+	case OnYeet:
+		return "yeet"
+	case OnWrap:
+		return "wrap"
+	case OnCollect:
+		return "collect"
+	default:
+		return "unknown"
+	}
+}
+
+// Hook is called with each Error created by the definitions it's added to (see ErrorDefined.Hook and AddHook),
+// including the ErrUncaught errors created by Explainf (for errors that are not an Error), and the errors created by
+// Recover (with the "panic" prop set, and OnWrap if the panic value is an error). It's called once the props and the
+// trace of the error are set, but before the explanation (of Yeetf or Wrapf) is added. Panics of hooks are recovered
+// and ignored, such that they never prevent errors from being returned.
+type Hook = func(event HookEvent, err Error)
+
+type hookEntry struct {
+	hook Hook
+}
+
+var (
+	hooksMu sync.Mutex
+	hooks   atomic.Pointer[[]*hookEntry]
+)
+
+// AddHook adds the hook, called with the errors created by all definitions (after the hooks of the definition, see
+// ErrorDefined.Hook). Returns a function removing the hook.
+func AddHook(hook Hook) (remove func()) {
+	entry := &hookEntry{hook: hook}
+
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	var entries []*hookEntry
+	if current := hooks.Load(); current != nil {
+		entries = slices.Clone(*current)
+	}
+
+	entries = append(entries, entry)
+	hooks.Store(&entries)
+
+	return func() {
+		hooksMu.Lock()
+		defer hooksMu.Unlock()
+
+		current := hooks.Load()
+		if current == nil {
+			return
+		}
+
+		entries := slices.DeleteFunc(slices.Clone(*current), func(e *hookEntry) bool {
+			return e == entry
+		})
+
+		if len(entries) == 0 {
+			hooks.Store(nil)
+		} else {
+			hooks.Store(&entries)
+		}
+	}
+}
+
+// Hook adds the hook to the definition, called with each Error created by it (or by its children created afterwards,
+// see Child).
+func (defined *errorDefined) Hook(hook Hook) *errorDefined {
+	defined.hooks = append(defined.hooks, hook)
+	return defined
+}
+
+// runHooks calls the hooks of the definition, followed by the global hooks, with the given error.
+func (defined *errorDefined) runHooks(event HookEvent, err *errorImpl) {
+	global := hooks.Load()
+	if len(defined.hooks) == 0 && global == nil {
+		return
+	}
+
+	for _, hook := range defined.hooks {
+		runHook(hook, event, err)
+	}
+
+	if global != nil {
+		for _, entry := range *global {
+			runHook(entry.hook, event, err)
+		}
+	}
+}
+
+func runHook(hook Hook, event HookEvent, err Error) {
+	defer func() {
+		_ = recover()
+	}()
+
+	hook(event, err)
+}
@@ -0,0 +1,144 @@
+package oops_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+func TestHook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("definition", func(t *testing.T) {
+		t.Parallel()
+
+		var events []string
+
+		defined := oops.Define("code", "test.hook").Trace().Hook(func(event oops.HookEvent, err oops.Error) {
+			code, _ := err.Get("code")
+			events = append(events, event.String()+" "+code.(string)) //nolint:forcetypeassert
+
+			if len(err.Trace()) == 0 || !strings.HasSuffix(err.Trace()[0], ": TestHook.func1") {
+				t.Errorf("expected the trace to be captured, got %v", err.Trace())
+			}
+		})
+
+		defined.Yeet()
+		defined.Yeetf("explained")
+		defined.Wrap(errors.New("foreign"))
+		defined.Wrapf(errors.New("foreign"), "explained")
+		defined.Wrap(nil)
+
+		want := []string{"yeet test.hook", "yeet test.hook", "wrap test.hook", "wrap test.hook", "yeet test.hook"}
+		if !slices.Equal(events, want) {
+			t.Fatalf("unexpected events\n got: %q\nwant: %q", events, want)
+		}
+
+		defined.Child("code", "test.hook.child").Yeet()
+		if events[len(events)-1] != "yeet test.hook.child" {
+			t.Fatalf("expected the child to inherit the hook, got %q", events)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		defined := oops.Define("code", "test.hook.panic").
+			Hook(func(oops.HookEvent, oops.Error) { panic("boom") }).
+			Hook(func(oops.HookEvent, oops.Error) { called = true })
+
+		if err := defined.Yeet(); err == nil || !called {
+			t.Fatal("expected the panic of a hook not to prevent the error and the other hooks")
+		}
+	})
+
+	t.Run("global", func(t *testing.T) {
+		t.Parallel()
+
+		defined := oops.Define("code", "test.hook.global")
+
+		var (
+			mu    sync.Mutex
+			count int
+		)
+
+		remove := oops.AddHook(func(_ oops.HookEvent, err oops.Error) {
+			if err.Source() == defined {
+				mu.Lock()
+				count++
+				mu.Unlock()
+			}
+		})
+
+		defined.Yeet()
+		remove()
+		remove()
+		defined.Yeet()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if count != 1 {
+			t.Fatalf("expected the hook to be called once, got %d", count)
+		}
+	})
+	t.Run("uncaught", func(t *testing.T) {
+		t.Parallel()
+
+		foreign := errors.New("foreign")
+
+		var (
+			mu     sync.Mutex
+			events []oops.HookEvent
+		)
+
+		remove := oops.AddHook(func(event oops.HookEvent, err oops.Error) {
+			if err.Source() == oops.ErrUncaught && errors.Is(err, foreign) {
+				mu.Lock()
+				events = append(events, event)
+				mu.Unlock()
+			}
+		})
+		defer remove()
+
+		_ = oops.Explainf(foreign, "explained")
+		_ = oops.MustAny(foreign)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !slices.Equal(events, []oops.HookEvent{oops.OnWrap, oops.OnWrap}) {
+			t.Fatalf("expected 2 wraps, got %v", events)
+		}
+	})
+
+	t.Run("recovered", func(t *testing.T) {
+		t.Parallel()
+
+		var events []string
+
+		defined := oops.Define("code", "test.hook.recovered").Hook(func(event oops.HookEvent, err oops.Error) {
+			value, _ := err.Get("panic")
+			events = append(events, fmt.Sprintf("%s %v %d", event, value, len(err.Trace())))
+		})
+
+		recovering := func(value any) (err error) {
+			defer oops.Recover(&err, defined)
+			panic(value)
+		}
+
+		_ = recovering("boom")
+		_ = recovering(errors.ErrUnsupported)
+
+		if len(events) != 2 || !strings.HasPrefix(events[0], "yeet boom ") || events[0] == "yeet boom 0" ||
+			!strings.HasPrefix(events[1], "wrap unsupported operation ") {
+			t.Fatalf("unexpected events %q", events)
+		}
+	})
+}
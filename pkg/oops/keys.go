@@ -0,0 +1,139 @@
+package oops
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	keysMu sync.RWMutex
+	keys   = make(map[string]reflect.Type)
+)
+
+// TypedKey is a prop key whose values are of type T, created with Key.
+type TypedKey[T any] struct {
+	name string
+}
+
+// Prop is a single key-value pair, which can be given to Define (or Child) instead of the key and value arguments,
+// such as the one returned by TypedKey.Is.
+type Prop struct {
+	Key   string
+	Value any
+}
+
+// Key creates a TypedKey with the given name. Once created, the values given to Define (or Child, or the Set builder
+// of ErrorDefined) for the name must be of type T, otherwise they panic. The definitions created before the key (such
+// as package level definitions initialized before it) are checked by Key, which panics if any of them holds a value of
+// another type for the name. Key panics if a key with the same name was created with a different type.
+//
+// As with props, the names are shared by all packages: prefer qualified names (such as "billing.retries") for keys
+// which are not meant to be shared, such that unrelated packages do not clash on common names.
+func Key[T any](name string) TypedKey[T] {
+	t := reflect.TypeFor[T]()
+
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	if existing, ok := keys[name]; ok {
+		if existing != t {
+			panic(fmt.Sprintf("oops: Key %q already created with type %s, not %s", name, existing, t))
+		}
+
+		return TypedKey[T]{name: name}
+	}
+
+	// the registry is read before locking the props, as Registry methods lock the props while holding the registry
+	all := DefaultRegistry.All()
+
+	definedMu.RLock()
+	defer definedMu.RUnlock()
+
+	for _, defined := range all {
+		if value, ok := defined.(*errorDefined).props[name]; ok && !keyAccepts(t, value) { //nolint:forcetypeassert
+			panic(fmt.Sprintf("oops: prop %q of %#v must be of type %s (see Key), got %T", name, defined, t, value))
+		}
+	}
+
+	keys[name] = t
+
+	return TypedKey[T]{name: name}
+}
+
+// Name returns the name of the key, being the key of the prop.
+func (key TypedKey[T]) Name() string {
+	return key.name
+}
+
+// Get returns the value of the prop of the first Error in the unwrap chain of the given error. Returns false if there
+// is no Error, if the prop is not set, or if its value is not of type T.
+func (key TypedKey[T]) Get(err error) (T, bool) {
+	var (
+		zero T
+		v    Error
+	)
+
+	if !errors.As(Normalize(err), &v) {
+		return zero, false
+	}
+
+	value, ok := v.Get(key.name)
+	if !ok {
+		return zero, false
+	}
+
+	typed, ok := value.(T)
+
+	return typed, ok
+}
+
+// Lookup returns the value of the prop searched across the given error tree (see Lookup). Values not of type T are
+// skipped.
+func (key TypedKey[T]) Lookup(err error, options ...LookupOption) (T, bool) {
+	for _, value := range LookupAll(err, key.name, options...) {
+		if typed, ok := value.(T); ok {
+			return typed, true
+		}
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+// Set sets the prop on the given Error (see Error.Set), returning it.
+func (key TypedKey[T]) Set(err Error, value T) Error { //nolint:ireturn
+	return err.Set(key.name, value)
+}
+
+// Is returns the Prop setting the key to the given value, to be given to Define (or Child).
+func (key TypedKey[T]) Is(value T) Prop {
+	return Prop{Key: key.name, Value: value}
+}
+
+// checkKey panics if the name was created as a TypedKey of a type the given value is not assignable to.
+func checkKey(name string, value any) {
+	keysMu.RLock()
+	t, ok := keys[name]
+	keysMu.RUnlock()
+
+	if ok && !keyAccepts(t, value) {
+		panic(fmt.Sprintf("oops: prop %q must be of type %s (see Key), got %T", name, t, value))
+	}
+}
+
+// keyAccepts returns true if the value is assignable to the type, or is nil and the type is nilable.
+func keyAccepts(t reflect.Type, value any) bool {
+	if value != nil {
+		return reflect.TypeOf(value).AssignableTo(t)
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return true
+	default:
+		return false
+	}
+}
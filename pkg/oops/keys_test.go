@@ -0,0 +1,124 @@
+package oops_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var (
+	keyTestStatus = oops.Key[int]("test.keys.status")
+	keyTestCause  = oops.Key[error]("test.keys.cause")
+
+	errTestKeys = oops.Define("code", "test.keys", keyTestStatus.Is(404))
+)
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("wrapped: %w", errTestKeys.Yeet())
+		if status, ok := keyTestStatus.Get(err); !ok || status != 404 {
+			t.Fatalf("expected 404, got %d (%t)", status, ok)
+		}
+
+		if _, ok := keyTestStatus.Get(errors.New("foreign")); ok {
+			t.Fatal("expected no status for foreign errors")
+		}
+
+		if _, ok := keyTestStatus.Get(errTest.Yeet()); ok {
+			t.Fatal("expected no status for errors without the prop")
+		}
+	})
+
+	t.Run("set", func(t *testing.T) {
+		t.Parallel()
+
+		err := keyTestStatus.Set(errTestKeys.Yeet(), 410)
+		if status, _ := keyTestStatus.Get(err); status != 410 {
+			t.Fatalf("expected 410, got %d", status)
+		}
+
+		if status, _ := keyTestStatus.Get(errTestKeys.Yeet()); status != 404 {
+			t.Fatalf("expected the definition to keep 404, got %d", status)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		errTestKeys.Child(keyTestCause.Is(nil), "test.keys.cause", nil)
+	})
+
+	t.Run("same type", func(t *testing.T) {
+		t.Parallel()
+
+		if oops.Key[int]("test.keys.status").Name() != keyTestStatus.Name() {
+			t.Fatal("expected the same key")
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, `prop "test.keys.status" must be of type int (see Key), got string`, func() {
+			oops.Define("test.keys.status", "404")
+		})
+
+		assertPanics(t, `prop "test.keys.status" must be of type int (see Key), got string`, func() {
+			oops.Define().Set("test.keys.status", "404")
+		})
+
+		assertPanics(t, `Key "test.keys.status" already created with type int, not string`, func() {
+			oops.Key[string]("test.keys.status")
+		})
+	})
+
+	t.Run("defined before", func(t *testing.T) {
+		t.Parallel()
+
+		oops.Define("test.keys.before", "three")
+		oops.Define("test.keys.before.ok", 3)
+
+		assertPanics(t, `prop "test.keys.before" of oops.Define("test.keys.before", "three") must be of type int`, func() {
+			oops.Key[int]("test.keys.before")
+		})
+
+		assertPanics(t, `prop "test.keys.before" of oops.Define("test.keys.before", "three") must be of type int`, func() {
+			oops.Key[int]("test.keys.before")
+		})
+
+		oops.Key[int]("test.keys.before.ok")
+	})
+
+	t.Run("arguments", func(t *testing.T) {
+		t.Parallel()
+
+		assertPanics(t, "requires string keys (or Prop values), got int at argument 0", func() {
+			oops.Define(1, "one") //nolint:oopsvet // checks the panic
+		})
+
+		assertPanics(t, "requires an even number of arguments", func() {
+			oops.Define(keyTestStatus.Is(404), "code") //nolint:oopsvet // checks the panic
+		})
+	})
+}
+
+func assertPanics(t *testing.T, want string, fn func()) {
+	t.Helper()
+
+	defer func() {
+		t.Helper()
+
+		if r := fmt.Sprint(recover()); !strings.Contains(r, want) {
+			t.Fatalf("expected panic containing %q, got %q", want, r)
+		}
+	}()
+
+	fn()
+}
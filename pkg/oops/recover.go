@@ -0,0 +1,84 @@
+package oops
+
+import (
+	"runtime"
+
+	"go.sdls.io/oops/internal/unsafe"
+)
+
+// RepanicPolicy decides if a recovered panic value must be panicked again, instead of being converted into an Error.
+type RepanicPolicy = func(value any) bool
+
+// RepanicRuntimeErrors is a RepanicPolicy that panics again on runtime errors (such as nil dereferences or out of
+// range indexes), as they are usually programming errors.
+func RepanicRuntimeErrors(value any) bool {
+	_, ok := value.(runtime.Error)
+	return ok
+}
+
+// Recover must be deferred (eg: defer oops.Recover(&err, ErrPanic)) and converts any panic of the deferring function
+// into an Error of the given ErrorDefined (or ErrUncaught if nil), stored in errp. The panic value is set as the
+// "panic" prop and the trace is always captured, starting at the function that panicked. If the panic value is an
+// error, it becomes the parent of the Error. If any of the given policies return true, the value is panicked again.
+func Recover(errp *error, defined ErrorDefined, repanic ...RepanicPolicy) {
+	value := recover()
+	if value == nil {
+		return
+	}
+
+	for _, policy := range repanic {
+		if policy(value) {
+			panic(value)
+		}
+	}
+
+	*errp = recovered(value, defined)
+}
+
+// Go calls fn in a new goroutine, converting any panic into an ErrUncaught (see Recover). The returned function waits
+// for fn to return and returns its error.
+func Go(fn func() error, repanic ...RepanicPolicy) func() error {
+	var (
+		err  error
+		done = make(chan struct{})
+	)
+
+	go func() {
+		defer close(done)
+		defer Recover(&err, nil, repanic...)
+
+		err = fn()
+	}()
+
+	return func() error {
+		<-done
+		return err
+	}
+}
+
+func recovered(value any, defined ErrorDefined) Error { //nolint:ireturn
+	if defined == nil {
+		defined = ErrUncaught
+	}
+
+	parent, _ := value.(error)
+
+	vd, ok := defined.(*errorDefined)
+	if !ok || vd == nil {
+		err := defined.Wrapf(parent, "panic: %v", value)
+		return err.Set("panic", value)
+	}
+
+	event := OnYeet
+	if parent != nil {
+		event = OnWrap
+	}
+
+	err := vd.newErrorUntraced(parent)
+	err.trace = unsafe.CapturePanic(3)
+	err.Set("panic", value)
+	vd.runHooks(event, err)
+	err.explain(0, "panic: %v", value)
+
+	return err
+}
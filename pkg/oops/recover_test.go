@@ -0,0 +1,170 @@
+package oops_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+var errTestPanic = oops.Define("code", "test.panic")
+
+func recoverPanicking(value any) (err error) {
+	defer oops.Recover(&err, errTestPanic)
+
+	recoverPanicSite(value)
+
+	return nil
+}
+
+func recoverPanicSite(value any) {
+	panic(value)
+}
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	t.Run("value", func(t *testing.T) {
+		t.Parallel()
+
+		err := recoverPanicking("boom")
+
+		v, ok := oops.As(err, errTestPanic)
+		if !ok {
+			t.Fatalf("expected recovered error, got %v", err)
+		}
+
+		if v.Explanation() != "panic: boom" {
+			t.Fatalf("unexpected explanation: %q", v.Explanation())
+		}
+
+		if value, _ := v.Get("panic"); value != "boom" {
+			t.Fatalf("expected panic value in props, got %v", value)
+		}
+
+		if v.Unwrap() != nil {
+			t.Fatal("expected no parent for non-error panic values")
+		}
+
+		trace := v.Trace()
+		if len(trace) == 0 || !strings.HasSuffix(trace[0], ": recoverPanicSite") {
+			t.Fatalf("expected trace to start at the panic site, got %v", trace)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		cause := errors.New("cause")
+		err := recoverPanicking(cause)
+
+		if !errors.Is(err, errTestPanic) || !errors.Is(err, cause) {
+			t.Fatalf("expected recovered error with the panic error as parent, got %v", err)
+		}
+	})
+
+	t.Run("runtime error", func(t *testing.T) {
+		t.Parallel()
+
+		err := func() (err error) {
+			defer oops.Recover(&err, nil)
+
+			var m map[string]int
+			m["boom"]++
+
+			return nil
+		}()
+
+		v, ok := oops.As(err, oops.ErrUncaught)
+		if !ok {
+			t.Fatalf("expected nil definition to recover as ErrUncaught, got %v", err)
+		}
+
+		if trace := v.Trace(); len(trace) == 0 || !strings.HasSuffix(trace[0], ": TestRecover.func3.1") {
+			t.Fatalf("expected trace to start at the panic site, got %v", trace)
+		}
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		t.Parallel()
+
+		err := func() (err error) {
+			defer oops.Recover(&err, errTestPanic)
+			return nil
+		}()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("repanic", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected runtime error to be panicked again")
+			}
+		}()
+
+		_ = func() (err error) {
+			defer oops.Recover(&err, errTestPanic, oops.RepanicRuntimeErrors)
+
+			var m map[string]int
+			m["boom"]++
+
+			return nil
+		}()
+	})
+
+	t.Run("no repanic", func(t *testing.T) {
+		t.Parallel()
+
+		err := func() (err error) {
+			defer oops.Recover(&err, errTestPanic, oops.RepanicRuntimeErrors)
+			panic("boom")
+		}()
+
+		if !errors.Is(err, errTestPanic) {
+			t.Fatalf("expected recovered error, got %v", err)
+		}
+	})
+}
+
+func TestGo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		wait := oops.Go(func() error {
+			return errTest.Yeet()
+		})
+
+		if err := wait(); !errors.Is(err, errTest) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		wait := oops.Go(func() error {
+			panic("boom")
+		})
+
+		err := wait()
+		if !errors.Is(err, oops.ErrUncaught) || err.Error() != "uncaught unwrapped: panic: boom" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		if err := oops.Go(func() error { return nil })(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
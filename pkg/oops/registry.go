@@ -0,0 +1,224 @@
+package oops
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultRegistry records every ErrorDefined created by Define, identified by their "code" prop.
+var DefaultRegistry = NewRegistry("code")
+
+// definedGeneration is incremented each time the props of any ErrorDefined change after Define, such that registries
+// know when to rebuild their index.
+var definedGeneration atomic.Uint64
+
+// Registry records ErrorDefined values and identifies them by the value of a single prop (the key). Definitions can be
+// registered before their key prop is set (eg: using the Set builder after Define), as the index is rebuilt whenever
+// the props of any definition change. Definitions without the key prop are recorded, but cannot be looked up.
+type Registry struct {
+	key string
+
+	mu              sync.RWMutex
+	defined         []*errorDefined
+	registered      map[*errorDefined]struct{}
+	index           map[string]*errorDefined
+	indexLen        int
+	indexGeneration uint64
+}
+
+// NewRegistry returns an empty Registry identifying definitions by the given key prop.
+func NewRegistry(key string) *Registry {
+	return &Registry{
+		key: key,
+	}
+}
+
+// Key returns the prop used by the Registry to identify definitions.
+func (registry *Registry) Key() string {
+	return registry.key
+}
+
+// Register records the given definitions. Only definitions created by Define can be registered. Definitions already
+// registered (such as the ones created by Define, in DefaultRegistry) are ignored.
+func (registry *Registry) Register(defined ...ErrorDefined) *Registry {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	for _, d := range defined {
+		vd, ok := d.(*errorDefined)
+		if !ok || vd == nil {
+			panic("oops: Registry can only register ErrorDefined created by Define")
+		}
+
+		if _, ok := registry.registered[vd]; ok {
+			continue
+		}
+
+		if registry.registered == nil {
+			registry.registered = make(map[*errorDefined]struct{})
+		}
+
+		registry.registered[vd] = struct{}{}
+		registry.defined = append(registry.defined, vd)
+	}
+
+	return registry
+}
+
+// Unregister removes the given definitions from the Registry, such as the definitions created at runtime (rather than
+// as package level variables), which DefaultRegistry would otherwise retain. Definitions not registered are ignored.
+func (registry *Registry) Unregister(defined ...ErrorDefined) *Registry {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	for _, d := range defined {
+		vd, ok := d.(*errorDefined)
+		if !ok {
+			continue
+		}
+
+		if _, ok := registry.registered[vd]; !ok {
+			continue
+		}
+
+		delete(registry.registered, vd)
+		registry.defined = slices.DeleteFunc(registry.defined, func(e *errorDefined) bool {
+			return e == vd
+		})
+
+		// forces the index to be rebuilt, as its length may match again once other definitions are registered
+		registry.indexLen = -1
+	}
+
+	return registry
+}
+
+// Lookup returns the first registered definition whose key prop matches the given identifier. Identifiers are
+// compared by their default format (as in fmt.Sprint), such that a status of 404 can be looked up as "404".
+func (registry *Registry) Lookup(id any) (ErrorDefined, bool) { //nolint:ireturn
+	defined := registry.lookup(fmt.Sprint(id))
+	if defined == nil {
+		return nil, false
+	}
+
+	return defined, true
+}
+
+// All returns every registered definition, in the order they were registered.
+func (registry *Registry) All() []ErrorDefined {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	all := make([]ErrorDefined, len(registry.defined))
+	for idx, defined := range registry.defined {
+		all[idx] = defined
+	}
+
+	return all
+}
+
+// Duplicates returns all the identifiers used by more than one registered definition, along with said definitions in
+// the order they were registered.
+func (registry *Registry) Duplicates() map[string][]ErrorDefined {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	definedMu.RLock()
+	defer definedMu.RUnlock()
+
+	seen := make(map[string][]ErrorDefined, len(registry.defined))
+	for _, defined := range registry.defined {
+		id, ok := defined.props[registry.key]
+		if !ok {
+			continue
+		}
+
+		key := fmt.Sprint(id)
+		seen[key] = append(seen[key], defined)
+	}
+
+	duplicates := make(map[string][]ErrorDefined)
+	for id, defined := range seen {
+		if len(defined) > 1 {
+			duplicates[id] = defined
+		}
+	}
+
+	return duplicates
+}
+
+// Validate returns an error reporting each duplicate identifier (see Registry.Duplicates), or nil if there are none.
+func (registry *Registry) Validate() error {
+	duplicates := registry.Duplicates()
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(duplicates))
+	for id := range duplicates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	errs := make([]error, 0, len(ids))
+	for _, id := range ids {
+		errs = append(errs,
+			fmt.Errorf("oops: %s %q is used by %d definitions", registry.key, id, len(duplicates[id])))
+	}
+
+	return errors.Join(errs...)
+}
+
+// MustValidate panics with the error returned by Registry.Validate, if any. It is intended to be called from an init
+// function, after all the definitions were created.
+func (registry *Registry) MustValidate() {
+	if err := registry.Validate(); err != nil {
+		panic(err)
+	}
+}
+
+// Decode is the same as the package level Decode, resolving the definitions by the Registry key prop.
+func (registry *Registry) Decode(data []byte) (Error, error) { //nolint:ireturn
+	return decodeJSON(data, registry.key, registry.lookup)
+}
+
+func (registry *Registry) lookup(id string) *errorDefined {
+	generation := definedGeneration.Load()
+
+	registry.mu.RLock()
+	if registry.indexLen == len(registry.defined) && registry.indexGeneration == generation {
+		defined := registry.index[id]
+		registry.mu.RUnlock()
+
+		return defined
+	}
+	registry.mu.RUnlock()
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	definedMu.RLock()
+	defer definedMu.RUnlock()
+
+	registry.index = make(map[string]*errorDefined, len(registry.defined))
+	for _, defined := range registry.defined {
+		v, ok := defined.props[registry.key]
+		if !ok {
+			continue
+		}
+
+		key := fmt.Sprint(v)
+		if _, exists := registry.index[key]; !exists {
+			registry.index[key] = defined
+		}
+	}
+
+	registry.indexLen = len(registry.defined)
+	registry.indexGeneration = generation
+
+	return registry.index[id]
+}
@@ -0,0 +1,206 @@
+package oops_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	t.Parallel()
+
+	defined, ok := oops.DefaultRegistry.Lookup("test.err_test")
+	if !ok {
+		t.Fatal("expected Define to record errTest in the default registry")
+	}
+
+	if defined != errTest {
+		t.Fatal("unexpected definition")
+	}
+}
+
+func TestRegistry_Lookup_setAfterRegister(t *testing.T) {
+	t.Parallel()
+
+	late := oops.Define()
+	registry := oops.NewRegistry("code").Register(late)
+
+	if _, ok := registry.Lookup("late"); ok {
+		t.Fatal("expected definition without code not to be found")
+	}
+
+	late.Set("code", "late")
+	if defined, _ := registry.Lookup("late"); defined != late {
+		t.Fatal("expected definition to be found by a code set after Register")
+	}
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errA    = oops.Define("code", "a", "status", 400)
+		errB    = oops.Define("code", "b", "status", 404)
+		errNone = oops.Define("status", 500)
+	)
+
+	registry := oops.NewRegistry("code").Register(errA, errB, errNone)
+
+	if defined, ok := registry.Lookup("b"); !ok || defined != errB {
+		t.Fatal("expected to find b")
+	}
+
+	if _, ok := registry.Lookup("c"); ok {
+		t.Fatal("expected not to find c")
+	}
+
+	byStatus := oops.NewRegistry("status").Register(errA, errB, errNone)
+	if defined, ok := byStatus.Lookup(404); !ok || defined != errB {
+		t.Fatal("expected to find 404")
+	}
+
+	if defined, ok := byStatus.Lookup("500"); !ok || defined != errNone {
+		t.Fatal("expected identifiers to be compared by their format")
+	}
+
+	all := registry.All()
+	if len(all) != 3 || all[0] != errA || all[1] != errB || all[2] != errNone {
+		t.Fatalf("unexpected registered definitions: %v", len(all))
+	}
+}
+
+func TestRegistry_Validate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		errA  = oops.Define("code", "a")
+		errA2 = oops.Define("code", "a")
+		errB  = oops.Define("code", "b")
+	)
+
+	registry := oops.NewRegistry("code").Register(errA, errB)
+	if err := registry.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	registry.Register(errA2)
+
+	duplicates := registry.Duplicates()
+	if len(duplicates) != 1 || len(duplicates["a"]) != 2 || duplicates["a"][1] != errA2 {
+		t.Fatalf("unexpected duplicates: %v", duplicates)
+	}
+
+	err := registry.Validate()
+	if err == nil || err.Error() != `oops: code "a" is used by 2 definitions` {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	if defined, _ := registry.Lookup("a"); defined != errA {
+		t.Fatal("expected lookup to return the first registered definition")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("MustValidate must panic on duplicates")
+		}
+	}()
+
+	registry.MustValidate()
+}
+
+func TestRegistry_Register_twice(t *testing.T) {
+	t.Parallel()
+
+	errTwice := oops.Define("code", "test.registry.twice")
+
+	registry := oops.NewRegistry("code").Register(errTwice, errTwice).Register(errTwice)
+	if all := registry.All(); len(all) != 1 {
+		t.Fatalf("expected the definition once, got %d", len(all))
+	}
+
+	if err := registry.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	oops.DefaultRegistry.Register(errTwice)
+
+	count := 0
+	for _, defined := range oops.DefaultRegistry.All() {
+		if defined == errTwice {
+			count++
+		}
+	}
+
+	if count != 1 {
+		t.Fatalf("expected the definition once in DefaultRegistry, got %d", count)
+	}
+}
+
+func TestRegistry_Unregister(t *testing.T) {
+	t.Parallel()
+
+	errRuntime := oops.Define("code", "test.registry.runtime")
+	errChild := errRuntime.Child("code", "test.registry.runtime.child")
+	errKept := oops.Define("code", "test.registry.kept")
+
+	registry := oops.NewRegistry("code").Register(errRuntime, errKept)
+	if _, ok := registry.Lookup("test.registry.runtime"); !ok {
+		t.Fatal("expected the definition to be found before Unregister")
+	}
+
+	registry.Unregister(errRuntime, errChild).Register(errChild)
+
+	if _, ok := registry.Lookup("test.registry.runtime"); ok {
+		t.Fatal("expected the definition not to be found after Unregister")
+	}
+
+	if found, ok := registry.Lookup("test.registry.runtime.child"); !ok || found != errChild {
+		t.Fatal("expected the definition registered after Unregister to be found")
+	}
+
+	if all := registry.All(); len(all) != 2 {
+		t.Fatalf("expected 2 definitions, got %d", len(all))
+	}
+
+	oops.DefaultRegistry.Unregister(errRuntime, errChild)
+
+	for _, defined := range oops.DefaultRegistry.All() {
+		if defined == errRuntime || defined == errChild {
+			t.Fatal("expected the definitions to be removed from DefaultRegistry")
+		}
+	}
+}
+
+func TestRegistry_Register_panics(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Register must panic with a nil definition")
+		}
+	}()
+
+	oops.NewRegistry("code").Register(nil)
+}
+
+func TestRegistry_Decode(t *testing.T) {
+	t.Parallel()
+
+	errNotFound := oops.Define("code", "test.registry_not_found")
+	registry := oops.NewRegistry("code").Register(errNotFound)
+
+	data, err := json.Marshal(errNotFound.Yeetf("user %d", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := registry.Decode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Source() != errNotFound || decoded.Explanation() != "user 1" {
+		t.Fatalf("unexpected decoded error: %v", decoded)
+	}
+}
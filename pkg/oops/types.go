@@ -14,9 +14,9 @@ type Error interface {
 	// of a ErrorDefined.Collect).
 	Nested() []Error
 
-	// Is returns true if both errors are nil, or if the other error is a ErrorDefined and the Error.Source matches it,
-	// or if the other error is an Error, then both their Error.Source must match. Otherwise, the behaviour can be
-	// implementation-specific, but it's recommended to at least check any parent error if any.
+	// Is returns true if both errors are nil, or if the other error is a ErrorDefined and the Error.Source matches (or
+	// descends from) it, or if the other error is an Error, then both their Error.Source must match. Otherwise, the
+	// behaviour can be implementation-specific, but it's recommended to at least check any parent error if any.
 	Is(other error) bool
 
 	// As should check the target type to determine if it's a *Error, and if so, set the target to itself. This method
@@ -83,4 +83,6 @@ type ErrorCollectorFinish = func() Error
 
 type ErrorCollectorAdd = func(err error, path string, args ...any)
 
+type ErrorCollectorAddIndexed = func(idx int, err error, path string, args ...any)
+
 type Formatter = func(err Error) string
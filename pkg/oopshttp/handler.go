@@ -0,0 +1,234 @@
+package oopshttp
+
+import (
+	"bufio"
+	"html/template"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+// HandlerFunc is an HTTP handler that returns errors instead of writing them. HandlerFunc implements http.Handler,
+// writing any returned error with WriteError. Panics are recovered as oops.ErrUncaught (see oops.Recover) and written
+// the same way, except for http.ErrAbortHandler which is panicked again.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+var _ http.Handler = HandlerFunc(nil)
+
+// OnDroppedError is called by HandlerFunc with the errors (and recovered panics) that cannot be written, as the handler
+// already wrote the response headers (or hijacked the connection). By default, they are logged to the ErrorLog of the
+// http.Server (or the standard logger). Set it to nil to ignore them.
+var OnDroppedError = logDroppedError
+
+// ServeHTTP calls fn(w, r) and writes the returned error, if any. Errors are not written if the handler already wrote
+// the response headers, they are given to OnDroppedError instead.
+func (fn HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rw := &responseWriter{ResponseWriter: w}
+
+	err := oops.Normalize(fn.serve(rw, r))
+	if err == nil {
+		return
+	}
+
+	if rw.wroteHeader {
+		if onDropped := OnDroppedError; onDropped != nil {
+			onDropped(r, err)
+		}
+
+		return
+	}
+
+	WriteError(w, r, err)
+}
+
+func (fn HandlerFunc) serve(w http.ResponseWriter, r *http.Request) (err error) {
+	defer oops.Recover(&err, nil, repanicAbort)
+
+	return fn(w, r)
+}
+
+func repanicAbort(value any) bool {
+	return value == http.ErrAbortHandler //nolint:errorlint
+}
+
+func logDroppedError(r *http.Request, err error) {
+	logger := log.Default()
+	if server, ok := r.Context().Value(http.ServerContextKey).(*http.Server); ok && server.ErrorLog != nil {
+		logger = server.ErrorLog
+	}
+
+	logger.Printf("oopshttp: dropped error of %s %s, the response was already written: %v", r.Method, r.URL.Path, err)
+}
+
+// WriteError writes the given error as the response, using the status of the error (see Status) and the format
+// negotiated with the request Accept header: application/problem+json (the default, see WriteProblem), text/plain or
+// text/html. The text and HTML responses contain the same members as the Problem. Nothing is written if the error is
+// nil (including typed nil errors, see oops.Normalize).
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := NewProblem(err)
+	if problem == nil {
+		return
+	}
+
+	switch negotiate(r.Header.Get("Accept")) {
+	case "text/plain":
+		writeText(w, problem)
+	case "text/html":
+		writeHTML(w, problem)
+	default:
+		writeProblem(w, problem)
+	}
+}
+
+func writeText(w http.ResponseWriter, problem *Problem) {
+	var b strings.Builder
+
+	b.WriteString(strconv.Itoa(problem.Status))
+	b.WriteString(" ")
+	b.WriteString(problem.Title)
+
+	if problem.Detail != "" {
+		b.WriteString(": ")
+		b.WriteString(problem.Detail)
+	}
+
+	b.WriteString("\n")
+	writeTextErrors(&b, problem.Errors, 1)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_, _ = w.Write([]byte(b.String()))
+}
+
+func writeTextErrors(b *strings.Builder, entries []*ProblemError, depth int) {
+	for _, entry := range entries {
+		b.WriteString(strings.Repeat("\t", depth))
+
+		if entry.Path != "" {
+			b.WriteString(entry.Path)
+			b.WriteString(": ")
+		}
+
+		b.WriteString(entry.Title)
+
+		if entry.Detail != "" {
+			b.WriteString(": ")
+			b.WriteString(entry.Detail)
+		}
+
+		b.WriteString("\n")
+		writeTextErrors(b, entry.Errors, depth+1)
+	}
+}
+
+var htmlTemplate = template.Must(template.New("problem").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Status}} {{.Title}}</title></head>
+<body>
+<h1>{{.Status}} {{.Title}}</h1>
+{{- if .Detail}}
+<p>{{.Detail}}</p>
+{{- end}}
+{{- template "errors" .Errors}}
+</body>
+</html>
+{{define "errors"}}{{if .}}
+<ul>
+{{- range .}}
+<li>{{if .Path}}<code>{{.Path}}</code>: {{end}}{{.Title}}{{if .Detail}}: {{.Detail}}{{end}}
+{{- template "errors" .Errors}}</li>
+{{- end}}
+</ul>{{end}}{{end}}`))
+
+func writeHTML(w http.ResponseWriter, problem *Problem) {
+	var b strings.Builder
+	if err := htmlTemplate.Execute(&b, problem); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_, _ = w.Write([]byte(b.String()))
+}
+
+// negotiate returns the media type of the preferred response format, given the Accept header. Unsupported, invalid or
+// missing Accept headers (as well as wildcards) result in application/problem+json.
+func negotiate(accept string) string {
+	best, bestQ := ContentTypeProblem, 0.0
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		var format string
+		switch mediaType {
+		case ContentTypeProblem, "application/json", "application/*", "*/*":
+			format = ContentTypeProblem
+		case "text/plain":
+			format = "text/plain"
+		case "text/html", "application/xhtml+xml":
+			format = "text/html"
+		default:
+			continue
+		}
+
+		if q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+
+	return best
+}
+
+// responseWriter records if the handler wrote the response headers (or hijacked the connection). It forwards Flush and
+// Hijack to the original http.ResponseWriter, Hijack returning an error wrapping http.ErrNotSupported if it cannot.
+type responseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (rw *responseWriter) WriteHeader(statusCode int) {
+	rw.wroteHeader = true
+	rw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	return rw.ResponseWriter.Write(b) //nolint:wrapcheck
+}
+
+func (rw *responseWriter) Flush() {
+	rw.wroteHeader = true
+	_ = http.NewResponseController(rw.ResponseWriter).Flush()
+}
+
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := http.NewResponseController(rw.ResponseWriter).Hijack()
+	if err == nil {
+		rw.wroteHeader = true
+	}
+
+	return conn, buf, err //nolint:wrapcheck
+}
+
+// Unwrap allows http.ResponseController to access the original http.ResponseWriter.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,298 @@
+package oopshttp_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+	"go.sdls.io/oops/pkg/oopshttp"
+)
+
+func serve(t *testing.T, handler http.Handler, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	return rec
+}
+
+func TestHandlerFunc(t *testing.T) {
+	t.Parallel()
+
+	collected := oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		finish, addf := errValidation.Collect()
+		addf(errField.Yeetf("too <short>"), "name")
+
+		return oops.Explainf(finish(), "validating")
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		rec := serve(t, oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			_, _ = w.Write([]byte("hello"))
+			return nil
+		}), "")
+
+		if rec.Code != http.StatusOK || rec.Body.String() != "hello" {
+			t.Fatalf("unexpected response %d %q", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		for _, accept := range []string{"", "*/*", "application/json", "text/plain;q=0.5, application/json", "image/png"} {
+			rec := serve(t, collected, accept)
+
+			if ct := rec.Header().Get("Content-Type"); rec.Code != 422 || ct != oopshttp.ContentTypeProblem {
+				t.Fatalf("Accept %q: unexpected response %d %q", accept, rec.Code, ct)
+			}
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+
+		rec := serve(t, collected, "text/plain")
+
+		want := "422 invalid_request: validating\n\tname: invalid_field: too <short>\n"
+		if rec.Code != 422 || rec.Body.String() != want {
+			t.Fatalf("unexpected response %d\n got: %q\nwant: %q", rec.Code, rec.Body.String(), want)
+		}
+	})
+
+	t.Run("html", func(t *testing.T) {
+		t.Parallel()
+
+		rec := serve(t, collected, "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+		if ct := rec.Header().Get("Content-Type"); rec.Code != 422 || ct != "text/html; charset=utf-8" {
+			t.Fatalf("unexpected response %d %q", rec.Code, ct)
+		}
+
+		body := rec.Body.String()
+		if !strings.Contains(body, "<h1>422 invalid_request</h1>") ||
+			!strings.Contains(body, "<li><code>name</code>: invalid_field: too &lt;short&gt;</li>") {
+			t.Fatalf("unexpected body: %s", body)
+		}
+	})
+
+	t.Run("already written", func(t *testing.T) {
+		t.Parallel()
+
+		rec := serve(t, oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusAccepted)
+			return errAuth.Yeet()
+		}), "")
+
+		if rec.Code != http.StatusAccepted || rec.Body.Len() != 0 {
+			t.Fatalf("unexpected response %d %q", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+func TestHandlerFunc_panic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("value", func(t *testing.T) {
+		t.Parallel()
+
+		rec := serve(t, oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			panic("boom")
+		}), "text/plain")
+
+		if rec.Code != http.StatusInternalServerError || rec.Body.String() != "500 Internal Server Error\n" {
+			t.Fatalf("unexpected response %d %q", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		rec := serve(t, oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			panic(errors.New("boom"))
+		}), "")
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("unexpected response %d %q", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			if r := recover(); r != http.ErrAbortHandler { //nolint:errorlint
+				t.Fatalf("expected http.ErrAbortHandler to be re-panicked, got %v", r)
+			}
+		}()
+
+		serve(t, oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			panic(http.ErrAbortHandler)
+		}), "")
+	})
+}
+
+func TestWriteError_nil(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	oopshttp.WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), nil)
+
+	if rec.Body.Len() != 0 {
+		t.Fatal("nil error must not write a response")
+	}
+}
+
+func TestHandlerFunc_typedNil(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		for _, accept := range []string{"", "text/plain", "text/html"} {
+			rec := serve(t, oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				return oops.NilErr //nolint:oopsvet // checks NilErr is written as no error
+			}), accept)
+
+			if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+				t.Fatalf("Accept %q: expected NilErr to be no error, got %d %q", accept, rec.Code, rec.Body.String())
+			}
+		}
+	})
+
+	t.Run("wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		for _, accept := range []string{"", "text/plain", "text/html"} {
+			rec := serve(t, oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				return fmt.Errorf("handler: %w", oops.NilErr)
+			}), accept)
+
+			if rec.Code != http.StatusInternalServerError {
+				t.Fatalf("Accept %q: expected 500, got %d", accept, rec.Code)
+			}
+		}
+	})
+}
+
+func TestHandlerFunc_flush(t *testing.T) {
+	var dropped []error
+	defer func(onDropped func(*http.Request, error)) { oopshttp.OnDroppedError = onDropped }(oopshttp.OnDroppedError)
+	oopshttp.OnDroppedError = func(r *http.Request, err error) { dropped = append(dropped, err) }
+
+	rec := serve(t, oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		w.(http.Flusher).Flush()
+		return errAuth.Yeet()
+	}), "")
+
+	if !rec.Flushed || rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Fatalf("unexpected response %d %q (flushed %t)", rec.Code, rec.Body.String(), rec.Flushed)
+	}
+
+	if len(dropped) != 1 || !errors.Is(dropped[0], errAuth) {
+		t.Fatalf("expected the error to be dropped, got %v", dropped)
+	}
+}
+
+func TestHandlerFunc_hijack(t *testing.T) {
+	dropped := make(chan error, 1)
+	defer func(onDropped func(*http.Request, error)) { oopshttp.OnDroppedError = onDropped }(oopshttp.OnDroppedError)
+	oopshttp.OnDroppedError = func(r *http.Request, err error) { dropped <- err }
+
+	server := httptest.NewServer(oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		conn, buf, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		_, _ = buf.WriteString("HTTP/1.1 204 No Content\r\nConnection: close\r\n\r\n")
+		_ = buf.Flush()
+
+		panic("boom")
+	}))
+	defer server.Close()
+
+	res, err := http.Get(server.URL) //nolint:noctx
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		t.Fatalf("unexpected response %d", res.StatusCode)
+	}
+
+	if err := <-dropped; !errors.Is(err, oops.ErrUncaught) {
+		t.Fatalf("expected the panic to be dropped, got %v", err)
+	}
+
+	rec := serve(t, oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		_, _, err := w.(http.Hijacker).Hijack()
+		return err
+	}), "text/plain")
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected the hijack error to be written, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+type syncBuffer struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (buffer *syncBuffer) Write(p []byte) (int, error) {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	return buffer.b.Write(p)
+}
+
+func (buffer *syncBuffer) String() string {
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+
+	return buffer.b.String()
+}
+
+func TestHandlerFunc_droppedLog(t *testing.T) {
+	t.Parallel()
+
+	var logged syncBuffer
+
+	server := httptest.NewUnstartedServer(oopshttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		_, _ = w.Write([]byte("partial"))
+		return errAuth.Yeet()
+	}))
+	server.Config.ErrorLog = log.New(&logged, "", 0)
+	server.Start()
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/dropped") //nolint:noctx
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+
+	if want := "oopshttp: dropped error of GET /dropped"; !strings.Contains(logged.String(), want) {
+		t.Fatalf("expected the error to be logged as %q, got %q", want, logged.String())
+	}
+}
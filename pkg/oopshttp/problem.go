@@ -0,0 +1,187 @@
+// Package oopshttp renders errors as HTTP responses, following the client perspective of an error: definitions props
+// (such as status, type and code) describe the error to the client, while explanations of server errors and any
+// error not yeeted by oops stay on the server.
+package oopshttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+// ContentTypeProblem is the media type of Problem, as defined by RFC 9457.
+const ContentTypeProblem = "application/problem+json"
+
+// Props used to render the Problem members. They are read from the props of the Error, which are set by the props of
+// its ErrorDefined.
+var (
+	PropStatus = "status"
+	PropType   = "type"
+	PropCode   = "code"
+)
+
+// Problem is a RFC 9457 problem details object. The Errors extension contains the Error.Nested errors, such as the
+// ones added with ErrorDefined.Collect.
+type Problem struct {
+	Type   string          `json:"type,omitempty"`
+	Title  string          `json:"title,omitempty"`
+	Status int             `json:"status,omitempty"`
+	Detail string          `json:"detail,omitempty"`
+	Errors []*ProblemError `json:"errors,omitempty"`
+}
+
+// ProblemError is an entry of the Problem.Errors extension, describing a single nested error and where it occurred.
+type ProblemError struct {
+	Type   string          `json:"type,omitempty"`
+	Title  string          `json:"title,omitempty"`
+	Detail string          `json:"detail,omitempty"`
+	Path   string          `json:"path,omitempty"`
+	Errors []*ProblemError `json:"errors,omitempty"`
+}
+
+// NewProblem returns the Problem describing the given error. Errors of oops.ErrUncaught and oops.ErrTODO, as well as
+// errors without any Error in their unwrap chain, are rendered as an opaque 500 problem. Otherwise, the status, type
+// and title (using the code) are read from the props, defaulting to a 500 status and the status text as title. The
+// detail is the Error.Explanation, but only for client errors (status below 500). Returns nil if the error is nil,
+// including typed nil errors (see oops.Normalize).
+func NewProblem(err error) *Problem {
+	err = oops.Normalize(err)
+	if err == nil {
+		return nil
+	}
+
+	var v oops.Error
+	if !errors.As(err, &v) || oops.Normalize(v) == nil ||
+		v.Source() == oops.ErrUncaught || v.Source() == oops.ErrTODO {
+		return &Problem{
+			Title:  http.StatusText(http.StatusInternalServerError),
+			Status: http.StatusInternalServerError,
+		}
+	}
+
+	status := Status(v)
+
+	problem := &Problem{
+		Type:   propString(v, PropType),
+		Title:  propString(v, PropCode),
+		Status: status,
+		Errors: problemErrors(v.Nested(), status, map[oops.Error]struct{}{v: {}}),
+	}
+
+	if problem.Title == "" {
+		problem.Title = http.StatusText(status)
+	}
+
+	if status < http.StatusInternalServerError {
+		problem.Detail = v.Explanation()
+	}
+
+	return problem
+}
+
+// WriteProblem writes the Problem describing the given error (see NewProblem) as the response, using its status.
+// Nothing is written if the error is nil (including typed nil errors, see oops.Normalize).
+func WriteProblem(w http.ResponseWriter, err error) {
+	if problem := NewProblem(err); problem != nil {
+		writeProblem(w, problem)
+	}
+}
+
+func writeProblem(w http.ResponseWriter, problem *Problem) {
+	body, errMarshal := json.Marshal(problem)
+	if errMarshal != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentTypeProblem)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_, _ = w.Write(body)
+}
+
+// Status returns the HTTP status of the given error, as read from its PropStatus prop. Returns 500 if the error is nil,
+// or if the prop is missing or is not an error status (400 to 599).
+func Status(err oops.Error) int {
+	if oops.Normalize(err) == nil {
+		return http.StatusInternalServerError
+	}
+
+	value, ok := err.Get(PropStatus)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+
+	var status int
+	switch v := value.(type) {
+	case int:
+		status = v
+	case int32:
+		status = int(v)
+	case int64:
+		status = int(v)
+	case uint16:
+		status = int(v)
+	case float64:
+		status = int(v)
+	case string:
+		status, _ = strconv.Atoi(v)
+	}
+
+	if status < http.StatusBadRequest || status > 599 {
+		return http.StatusInternalServerError
+	}
+
+	return status
+}
+
+func problemErrors(nested []oops.Error, status int, seen map[oops.Error]struct{}) []*ProblemError {
+	if len(nested) == 0 {
+		return nil
+	}
+
+	entries := make([]*ProblemError, 0, len(nested))
+	for _, n := range nested {
+		if oops.Normalize(n) == nil || n.Source() == oops.ErrUncaught || n.Source() == oops.ErrTODO {
+			continue
+		}
+
+		if _, ok := seen[n]; ok {
+			continue
+		}
+
+		seen[n] = struct{}{}
+		entry := &ProblemError{
+			Type:   propString(n, PropType),
+			Title:  propString(n, PropCode),
+			Path:   n.Path(),
+			Errors: problemErrors(n.Nested(), status, seen),
+		}
+		delete(seen, n)
+
+		if status < http.StatusInternalServerError {
+			entry.Detail = n.Explanation()
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func propString(err oops.Error, key string) string {
+	value, ok := err.Get(key)
+	if !ok {
+		return ""
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return ""
+	}
+
+	return s
+}
@@ -0,0 +1,193 @@
+package oopshttp_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+	"go.sdls.io/oops/pkg/oopshttp"
+)
+
+var (
+	errValidation = oops.Define("type", "validation", "code", "invalid_request", "status", 422)
+	errField      = oops.Define("type", "validation", "code", "invalid_field", "status", 422)
+	errAuth       = oops.Define("type", "auth", "code", "auth_expired", "status", 401)
+	errDatabase   = oops.Define("type", "internal", "code", "database", "status", 503)
+	errNoStatus   = oops.Define("code", "no_status")
+	errEarlyHints = oops.Define("code", "early_hints", "status", 103)
+)
+
+func TestWriteProblem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		body   string
+	}{
+		{
+			name:   "client error",
+			err:    errAuth.Yeetf("token expired"),
+			status: 401,
+			body:   `{"type":"auth","title":"auth_expired","status":401,"detail":"token expired"}`,
+		},
+		{
+			name:   "wrapped client error",
+			err:    fmt.Errorf("handler: %w", errAuth.Yeetf("token expired")),
+			status: 401,
+			body:   `{"type":"auth","title":"auth_expired","status":401,"detail":"token expired"}`,
+		},
+		{
+			name:   "server error hides detail",
+			err:    errDatabase.Wrapf(errors.New("dial tcp"), "loading user 7"),
+			status: 503,
+			body:   `{"type":"internal","title":"database","status":503}`,
+		},
+		{
+			name:   "no status",
+			err:    errNoStatus.Yeetf("oh no"),
+			status: 500,
+			body:   `{"title":"no_status","status":500}`,
+		},
+		{
+			name:   "uncaught",
+			err:    errors.New("secret connection string"),
+			status: 500,
+			body:   `{"title":"Internal Server Error","status":500}`,
+		},
+		{
+			name:   "wrapped typed nil",
+			err:    fmt.Errorf("handler: %w", oops.NilErr),
+			status: 500,
+			body:   `{"title":"Internal Server Error","status":500}`,
+		},
+		{
+			name:   "informational status",
+			err:    errEarlyHints.Yeetf("not an error status"),
+			status: 500,
+			body:   `{"title":"early_hints","status":500}`,
+		},
+		{
+			name:   "explicit uncaught",
+			err:    oops.Explainf(errors.New("secret"), "doing things"),
+			status: 500,
+			body:   `{"title":"Internal Server Error","status":500}`,
+		},
+		{
+			name: "collected",
+			err: func() error {
+				finish, addf := errValidation.Collect()
+				addf(errField.Yeetf("too short"), "name")
+				addf(errField.Yeetf("not a number"), "items[%d].count", 2)
+				addf(oops.ErrUncaught.Wrap(errors.New("secret")), "ignored")
+
+				return finish()
+			}(),
+			status: 422,
+			body: `{"type":"validation","title":"invalid_request","status":422,"errors":[` +
+				`{"type":"validation","title":"invalid_field","detail":"too short","path":"name"},` +
+				`{"type":"validation","title":"invalid_field","detail":"not a number","path":"items[2].count"}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			oopshttp.WriteProblem(rec, test.err)
+
+			if rec.Code != test.status {
+				t.Fatalf("unexpected status %d, want %d", rec.Code, test.status)
+			}
+
+			if ct := rec.Header().Get("Content-Type"); ct != oopshttp.ContentTypeProblem {
+				t.Fatalf("unexpected content type %q", ct)
+			}
+
+			if body := rec.Body.String(); body != test.body {
+				t.Fatalf("unexpected body\n got: %s\nwant: %s", body, test.body)
+			}
+		})
+	}
+}
+
+func TestWriteProblem_nil(t *testing.T) {
+	t.Parallel()
+
+	for _, err := range []error{nil, oops.NilErr} {
+		rec := httptest.NewRecorder()
+		oopshttp.WriteProblem(rec, err)
+
+		if rec.Body.Len() != 0 || rec.Header().Get("Content-Type") != "" {
+			t.Fatalf("%v error must not write a response", err)
+		}
+
+		if oopshttp.NewProblem(err) != nil {
+			t.Fatalf("%v error must not have a problem", err)
+		}
+	}
+}
+
+// TestNewProblem_foreign is not parallel, as it adds a global hook.
+func TestNewProblem_foreign(t *testing.T) {
+	hooked := 0
+	defer oops.AddHook(func(oops.HookEvent, oops.Error) { hooked++ })()
+
+	problem := oopshttp.NewProblem(fmt.Errorf("wrapped: %w", errors.New("foreign")))
+	if problem.Status != http.StatusInternalServerError || problem.Title != "Internal Server Error" {
+		t.Fatalf("expected an opaque 500 problem, got %+v", problem)
+	}
+
+	if hooked != 0 {
+		t.Fatalf("expected no error to be created, got %d hook calls", hooked)
+	}
+}
+
+func TestNewProblem_cycle(t *testing.T) {
+	t.Parallel()
+
+	err := errValidation.Yeet()
+	_ = err.Append(err, errField.Yeet().PathSetf("name"))
+
+	problem := oopshttp.NewProblem(err)
+	if len(problem.Errors) != 1 || problem.Errors[0].Path != "name" {
+		t.Fatalf("unexpected problem errors: %v", problem.Errors)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := map[any]int{
+		404:          404,
+		int64(409):   409,
+		float64(400): 400,
+		"429":        429,
+		"teapot":     500,
+		42:           500,
+		103:          500,
+		302:          500,
+		599:          599,
+		600:          500,
+	}
+
+	for value, want := range tests {
+		err := oops.Define().Yeet().Set("status", value)
+		if got := oopshttp.Status(err); got != want {
+			t.Fatalf("Status(%T(%v)) = %d, want %d", value, value, got, want)
+		}
+	}
+
+	if got := oopshttp.Status(oops.Define().Yeet()); got != http.StatusInternalServerError {
+		t.Fatalf("Status without prop = %d, want 500", got)
+	}
+
+	if got := oopshttp.Status(oops.NilErr); got != http.StatusInternalServerError {
+		t.Fatalf("Status of NilErr = %d, want 500", got)
+	}
+}
@@ -0,0 +1,212 @@
+// Package oopsmetrics counts the errors created by oops definitions, labelled by how they were created, by their
+// definition and by selected props, and exposes the counts in the Prometheus text exposition format, without depending
+// on a client library.
+package oopsmetrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+// ContentType is the media type of the Prometheus text exposition format written by Counter.ServeHTTP.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Name is the name of the metric written by Counter.
+const Name = "oops_errors_total"
+
+// LabelEvent is the label holding the oops.HookEvent of the counted errors, being "yeet", "wrap" or "collect".
+const LabelEvent = "event"
+
+// LabelDefined is the label holding the definition of the counted errors (see oops.DefinedID), such that the errors
+// of distinct definitions with the same props are not merged.
+const LabelDefined = "defined"
+
+// Other replaces the values of the prop labels of the errors counted once a Counter has reached its limit of series.
+const Other = "other"
+
+// DefaultProps are the props used as labels when none are given to NewCounter.
+var DefaultProps = []string{"code", "type", "status"}
+
+// Counter counts errors by event (see oops.HookEvent), by definition (see oops.DefinedID) and by the values of
+// selected props. It's an oops.Hook (see Counter.Observe), to be added to all definitions with oops.AddHook or to some
+// with ErrorDefined.Hook, and an http.Handler writing the counts. A Counter is safe for concurrent use.
+type Counter struct {
+	props  []string
+	labels []string
+	limit  int
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	values []string
+	count  uint64
+}
+
+// NewCounter returns a Counter labelling errors by the given props (DefaultProps if none), keeping at most limit
+// series. Once the limit is reached, errors of new series are counted in the series of their event whose definition
+// and prop labels are all Other, such that the cardinality stays bounded (at limit plus one series by event). Panics
+// if limit is not positive, or if two props have the same label name (such as "user-id" and "user_id").
+func NewCounter(limit int, props ...string) *Counter {
+	if limit <= 0 {
+		panic("oopsmetrics: NewCounter requires a positive limit")
+	}
+
+	if len(props) == 0 {
+		props = DefaultProps
+	}
+
+	labels := make([]string, len(props))
+	labelled := map[string]string{LabelEvent: LabelEvent, LabelDefined: LabelDefined}
+
+	for idx, prop := range props {
+		labels[idx] = labelName(prop)
+
+		if other, ok := labelled[labels[idx]]; ok {
+			panic(fmt.Sprintf("oopsmetrics: props %q and %q have the same label name %q", other, prop, labels[idx]))
+		}
+
+		labelled[labels[idx]] = prop
+	}
+
+	return &Counter{
+		props:  slices.Clone(props),
+		labels: labels,
+		limit:  limit,
+		series: make(map[string]*series),
+	}
+}
+
+// Observe counts the error, it's an oops.Hook.
+func (counter *Counter) Observe(event oops.HookEvent, err oops.Error) {
+	values := make([]string, 2+len(counter.props))
+	values[0] = event.String()
+	values[1] = oops.DefinedID(err.Source())
+
+	for idx, prop := range counter.props {
+		if value, ok := err.Get(prop); ok && value != nil {
+			values[idx+2] = fmt.Sprint(value)
+		}
+	}
+
+	key := strings.Join(values, "\x00")
+
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+
+	s, ok := counter.series[key]
+	if !ok {
+		if len(counter.series) >= counter.limit {
+			for idx := 1; idx < len(values); idx++ {
+				values[idx] = Other
+			}
+
+			key = strings.Join(values, "\x00")
+			s = counter.series[key]
+		}
+
+		if s == nil {
+			s = &series{values: values}
+			counter.series[key] = s
+		}
+	}
+
+	s.count++
+}
+
+// Count returns the number of errors counted with the given event and prop values (in the order of the props of the
+// Counter), of all definitions.
+func (counter *Counter) Count(event oops.HookEvent, values ...string) uint64 {
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+
+	var count uint64
+
+	for _, s := range counter.series {
+		if s.values[0] == event.String() && slices.Equal(s.values[2:], values) {
+			count += s.count
+		}
+	}
+
+	return count
+}
+
+// WriteTo writes the counts in the Prometheus text exposition format, sorted by label values.
+func (counter *Counter) WriteTo(w io.Writer) (int64, error) {
+	counter.mu.Lock()
+	all := make([]series, 0, len(counter.series))
+	for _, s := range counter.series {
+		all = append(all, *s)
+	}
+	counter.mu.Unlock()
+
+	slices.SortFunc(all, func(a, b series) int {
+		return slices.Compare(a.values, b.values)
+	})
+
+	var b strings.Builder
+
+	b.WriteString("# HELP " + Name + " Errors created by oops definitions.\n")
+	b.WriteString("# TYPE " + Name + " counter\n")
+
+	for _, s := range all {
+		b.WriteString(Name + "{" + LabelEvent + "=" + labelValue(s.values[0]))
+		b.WriteString("," + LabelDefined + "=" + labelValue(s.values[1]))
+
+		for idx, label := range counter.labels {
+			b.WriteString("," + label + "=" + labelValue(s.values[idx+2]))
+		}
+
+		b.WriteString("} " + strconv.FormatUint(s.count, 10) + "\n")
+	}
+
+	n, err := io.WriteString(w, b.String())
+
+	return int64(n), err
+}
+
+// ServeHTTP writes the counts in the Prometheus text exposition format.
+func (counter *Counter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	_, _ = counter.WriteTo(w)
+}
+
+// labelName returns the prop as a valid label name, replacing the invalid characters with underscores. The props
+// named as LabelEvent or LabelDefined are prefixed with "prop_".
+func labelName(prop string) string {
+	if prop == LabelEvent || prop == LabelDefined {
+		return "prop_" + prop
+	}
+
+	var b strings.Builder
+
+	for idx, r := range prop {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', idx > 0 && r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+
+	if b.Len() == 0 {
+		return "_"
+	}
+
+	return b.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labelValue returns the quoted label value, escaping backslashes, double quotes and line feeds only.
+func labelValue(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
@@ -0,0 +1,176 @@
+package oopsmetrics_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+	"go.sdls.io/oops/pkg/oopsmetrics"
+)
+
+func TestCounter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("events", func(t *testing.T) {
+		t.Parallel()
+
+		counter := oopsmetrics.NewCounter(10)
+
+		errNotFound := oops.Define("type", "user", "code", "not_found", "status", 404).Hook(counter.Observe)
+		errInvalid := oops.Define("type", "validation", "code", `in"valid`).Hook(counter.Observe)
+
+		errNotFound.Yeetf("user %d", 1)
+		errNotFound.Yeetf("user %d", 2)
+		errNotFound.Wrap(errors.New("no rows"))
+
+		finish, addf := errInvalid.Collect()
+		addf(errNotFound.Yeet(), "user")
+		finish()
+
+		if count := counter.Count(oops.OnYeet, "not_found", "user", "404"); count != 3 {
+			t.Fatalf("expected 3 yeets, got %d", count)
+		}
+
+		rec := httptest.NewRecorder()
+		counter.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		if rec.Header().Get("Content-Type") != oopsmetrics.ContentType {
+			t.Fatalf("unexpected content type %q", rec.Header().Get("Content-Type"))
+		}
+
+		want := "# HELP oops_errors_total Errors created by oops definitions.\n" +
+			"# TYPE oops_errors_total counter\n" +
+			`oops_errors_total{event="collect",defined="in\"valid",code="in\"valid",type="validation",status=""} 1` + "\n" +
+			`oops_errors_total{event="wrap",defined="not_found",code="not_found",type="user",status="404"} 1` + "\n" +
+			`oops_errors_total{event="yeet",defined="not_found",code="not_found",type="user",status="404"} 3` + "\n"
+		if rec.Body.String() != want {
+			t.Fatalf("unexpected body\n got: %s\nwant: %s", rec.Body.String(), want)
+		}
+	})
+
+	t.Run("cardinality", func(t *testing.T) {
+		t.Parallel()
+
+		counter := oopsmetrics.NewCounter(2, "code", "user-id", "event")
+		defined := oops.Define("code", "limited").Hook(counter.Observe)
+
+		for idx := range 5 {
+			defined.Yeet().Set("user-id", idx)
+			defined.Yeet().Set("user-id", idx)
+		}
+
+		if count := counter.Count(oops.OnYeet, "limited", "", ""); count != 10 {
+			t.Fatalf("expected the props set after creation not to be counted, got %d", count)
+		}
+
+		for idx := range 5 {
+			counter.Observe(oops.OnWrap, defined.Yeet().Set("user-id", idx))
+		}
+
+		if count := counter.Count(oops.OnWrap, oopsmetrics.Other, oopsmetrics.Other, oopsmetrics.Other); count != 4 {
+			t.Fatalf("expected the series over the limit to be merged, got %d", count)
+		}
+
+		rec := httptest.NewRecorder()
+		counter.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		id := oops.DefinedID(defined)
+
+		want := "# HELP oops_errors_total Errors created by oops definitions.\n" +
+			"# TYPE oops_errors_total counter\n" +
+			`oops_errors_total{event="wrap",defined="` + id + `",code="limited",user_id="0",prop_event=""} 1` + "\n" +
+			`oops_errors_total{event="wrap",defined="other",code="other",user_id="other",prop_event="other"} 4` + "\n" +
+			`oops_errors_total{event="yeet",defined="` + id + `",code="limited",user_id="",prop_event=""} 15` + "\n"
+		if rec.Body.String() != want {
+			t.Fatalf("unexpected body\n got: %s\nwant: %s", rec.Body.String(), want)
+		}
+	})
+
+	t.Run("definitions", func(t *testing.T) {
+		t.Parallel()
+
+		counter := oopsmetrics.NewCounter(10, "type")
+
+		first := oops.Define("type", "same").Hook(counter.Observe)
+		second := oops.Define("type", "same").Hook(counter.Observe)
+
+		first.Yeet()
+		second.Yeet()
+		second.Yeet()
+
+		if count := counter.Count(oops.OnYeet, "same"); count != 3 {
+			t.Fatalf("expected 3 yeets, got %d", count)
+		}
+
+		var b strings.Builder
+		_, _ = counter.WriteTo(&b)
+
+		for id, count := range map[string]int{oops.DefinedID(first): 1, oops.DefinedID(second): 2} {
+			line := fmt.Sprintf(`{event="yeet",defined=%q,type="same"} %d`, id, count)
+			if !strings.Contains(b.String(), line) {
+				t.Fatalf("expected the series %s, got:\n%s", line, b.String())
+			}
+		}
+	})
+
+	t.Run("collision", func(t *testing.T) {
+		t.Parallel()
+
+		for _, props := range [][]string{{"user-id", "user_id"}, {"event", "prop_event"}, {"code", "code"}} {
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Fatalf("expected NewCounter to panic for the props %q", props)
+					}
+				}()
+
+				oopsmetrics.NewCounter(10, props...)
+			}()
+		}
+
+		oopsmetrics.NewCounter(10, "event", "defined")
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected NewCounter to panic")
+			}
+		}()
+
+		oopsmetrics.NewCounter(0)
+	})
+}
+
+func TestCounter_uncaught(t *testing.T) {
+	counter := oopsmetrics.NewCounter(10, "panic")
+	defer oops.AddHook(counter.Observe)()
+
+	_ = oops.Explainf(errors.New("foreign"), "uncaught")
+
+	func() (err error) {
+		defer oops.Recover(&err, nil)
+		panic("boom")
+	}()
+
+	if count := counter.Count(oops.OnWrap, ""); count != 1 {
+		t.Fatalf("expected the uncaught error to be counted, got %d", count)
+	}
+
+	if count := counter.Count(oops.OnYeet, "boom"); count != 1 {
+		t.Fatalf("expected the recovered panic to be counted, got %d", count)
+	}
+
+	var b strings.Builder
+	_, _ = counter.WriteTo(&b)
+
+	if !strings.Contains(b.String(), `{event="wrap",defined="oops.ErrUncaught",panic=""} 1`) {
+		t.Fatalf("expected the errors to be counted as oops.ErrUncaught, got:\n%s", b.String())
+	}
+}
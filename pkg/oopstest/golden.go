@@ -0,0 +1,136 @@
+package oopstest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// EnvUpdate is the environment variable that, when set to a non-empty value, makes RequireGolden write the golden files
+// instead of comparing them (eg: OOPSTEST_UPDATE=1 go test ./...).
+const EnvUpdate = "OOPSTEST_UPDATE"
+
+var (
+	snapshotFrame   = regexp.MustCompile(`^(\t*)(.+):\d+ \(0x[0-9a-f]+\): (\S+)$`)
+	snapshotReturn  = regexp.MustCompile(`^(\t*).+:\d+ \((\S+)\)(: .*)?$`)
+	snapshotIgnored = []string{"/src/testing", "/src/runtime"}
+)
+
+// Snapshot returns the whole error tree (see Tree) with deterministic traces: each trace frame (and return trace frame)
+// is reduced to the name of its function (removing the file, line and program counter), and the frames of the standard
+// testing and runtime packages are removed.
+func Snapshot(err error) string {
+	lines := strings.SplitAfter(Tree(err), "\n")
+
+	var b strings.Builder
+	for _, line := range lines {
+		text := strings.TrimSuffix(line, "\n")
+
+		if match := snapshotFrame.FindStringSubmatch(text); match != nil {
+			if !ignoredFrame(match[2]) {
+				b.WriteString(match[1] + match[3] + "\n")
+			}
+
+			continue
+		}
+
+		if match := snapshotReturn.FindStringSubmatch(text); match != nil {
+			b.WriteString(match[1] + match[2] + match[3] + "\n")
+			continue
+		}
+
+		b.WriteString(line)
+	}
+
+	return b.String()
+}
+
+// RequireGolden requires the Snapshot of the error to be equal to the content of the golden file at the given path,
+// printing a line diff otherwise. If the EnvUpdate environment variable is set, the golden file is written instead.
+func RequireGolden(t TB, err error, path string) {
+	t.Helper()
+
+	got := Snapshot(err)
+
+	if os.Getenv(EnvUpdate) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gosec
+			t.Fatalf("oopstest: creating golden directory: %v", err)
+			return
+		}
+
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil { //nolint:gosec
+			t.Fatalf("oopstest: writing golden file: %v", err)
+		}
+
+		return
+	}
+
+	want, rerr := os.ReadFile(path)
+	if errors.Is(rerr, os.ErrNotExist) {
+		t.Fatalf("oopstest: golden file %s does not exist (set %s=1 to create it), got:\n%s", path, EnvUpdate, got)
+		return
+	} else if rerr != nil {
+		t.Fatalf("oopstest: reading golden file: %v", rerr)
+		return
+	}
+
+	if string(want) != got {
+		t.Fatalf("oopstest: error does not match golden file %s (-want +got):\n%s", path, Diff(string(want), got))
+	}
+}
+
+// Diff returns a line diff of the given texts (such as error trees), with removed lines prefixed by "- ", added lines
+// prefixed by "+ " and common lines prefixed by "  ".
+func Diff(want, got string) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			out.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			out.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			out.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+
+	return out.String()
+}
+
+func ignoredFrame(file string) bool {
+	dir := filepath.ToSlash(filepath.Dir(file))
+	for _, suffix := range snapshotIgnored {
+		if strings.HasSuffix(dir, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,168 @@
+// Package oopstest provides test helpers asserting on errors created by oops. All the helpers fail the test
+// immediately (using testing.TB.Fatalf), printing the whole error tree (as with the %+v verb) of the checked error.
+package oopstest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.sdls.io/oops/pkg/oops"
+)
+
+// TB is the subset of testing.TB used by the helpers.
+type TB interface {
+	Helper()
+	Fatalf(format string, args ...any)
+}
+
+// RequireIs requires the error to be (or to wrap) an Error of the given definition, as checked by errors.Is.
+func RequireIs(t TB, err error, target oops.ErrorDefined) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("oopstest: expected error of %s, got nil", definedName(target))
+		return
+	}
+
+	if !errors.Is(err, target) {
+		t.Fatalf("oopstest: expected error of %s, got:\n%s", definedName(target), Tree(err))
+	}
+}
+
+// RequireNested requires the error (or the first Error in its unwrap chain) to have a nested error (at any depth) of
+// the given definition and with the given Error.Path.
+func RequireNested(t TB, err error, target oops.ErrorDefined, path string) oops.Error { //nolint:ireturn
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("oopstest: expected error with nested %s at %q, got nil", definedName(target), path)
+		return nil
+	}
+
+	var v oops.Error
+	if errors.As(err, &v) && v != nil {
+		if nested := findNested(v, target, path, make(map[oops.Error]struct{})); nested != nil {
+			return nested
+		}
+	}
+
+	t.Fatalf("oopstest: expected error with nested %s at %q, got:\n%s", definedName(target), path, Tree(err))
+
+	return nil
+}
+
+// RequireProps requires the error (or the first Error in its unwrap chain) to have the given props, compared using
+// reflect.DeepEqual. Props not given are not checked.
+func RequireProps(t TB, err error, props map[string]any) {
+	t.Helper()
+
+	v := requireError(t, err)
+	if v == nil {
+		return
+	}
+
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var mismatches []string
+	for _, key := range keys {
+		want := props[key]
+
+		got, ok := v.Get(key)
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("\t%s: missing, want %s", key, describe(want)))
+		case !reflect.DeepEqual(got, want):
+			mismatches = append(mismatches, fmt.Sprintf("\t%s: got %s, want %s", key, describe(got), describe(want)))
+		}
+	}
+
+	if len(mismatches) != 0 {
+		t.Fatalf("oopstest: props mismatch:\n%s\nerror:\n%s", strings.Join(mismatches, "\n"), Tree(err))
+	}
+}
+
+// RequireExplanation requires the Error.Explanation of the error (or of the first Error in its unwrap chain) to
+// contain the given substring.
+func RequireExplanation(t TB, err error, substring string) {
+	t.Helper()
+
+	v := requireError(t, err)
+	if v == nil {
+		return
+	}
+
+	if !strings.Contains(v.Explanation(), substring) {
+		t.Fatalf("oopstest: expected explanation containing %q, got %q, error:\n%s",
+			substring, v.Explanation(), Tree(err))
+	}
+}
+
+// Tree returns the whole error tree, as printed with the %+v verb.
+func Tree(err error) string {
+	if err == nil {
+		return "<nil>\n"
+	}
+
+	var v oops.Error
+	if !errors.As(err, &v) {
+		return err.Error() + "\n"
+	}
+
+	return fmt.Sprintf("%+v", err)
+}
+
+func requireError(t TB, err error) oops.Error { //nolint:ireturn
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("oopstest: expected error, got nil")
+		return nil
+	}
+
+	var v oops.Error
+	if !errors.As(err, &v) || v == nil {
+		t.Fatalf("oopstest: expected oops.Error, got %T: %v", err, err)
+		return nil
+	}
+
+	return v
+}
+
+func findNested(err oops.Error, target oops.ErrorDefined, path string, seen map[oops.Error]struct{}) oops.Error { //nolint:ireturn,lll
+	if _, ok := seen[err]; ok {
+		return nil
+	}
+
+	seen[err] = struct{}{}
+
+	for _, nested := range err.Nested() {
+		if nested == nil {
+			continue
+		}
+
+		if nested.Path() == path && errors.Is(nested, target) {
+			return nested
+		}
+
+		if found := findNested(nested, target, path, seen); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+func definedName(defined oops.ErrorDefined) string {
+	return fmt.Sprintf("%#v", defined)
+}
+
+func describe(v any) string {
+	return fmt.Sprintf("%T(%#v)", v, v)
+}
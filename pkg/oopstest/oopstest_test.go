@@ -0,0 +1,175 @@
+package oopstest_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.sdls.io/oops/pkg/oops"
+	"go.sdls.io/oops/pkg/oopstest"
+)
+
+var (
+	errTest   = oops.Define("code", "oopstest.test")
+	errItem   = oops.Define("code", "oopstest.item", "status", 400)
+	errTraced = oops.Define("code", "oopstest.traced").Trace()
+)
+
+type fakeT struct {
+	failed  bool
+	message string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Fatalf(format string, args ...any) {
+	t.failed = true
+	t.message = fmt.Sprintf(format, args...)
+}
+
+func collected() error {
+	finish, addf := errTest.Collect()
+	addf(errItem.Yeetf("invalid"), "items[%d]", 1)
+	addf(errItem.Yeet(), "items[%d]", 2)
+
+	return finish()
+}
+
+func TestRequireIs(t *testing.T) {
+	t.Parallel()
+
+	ft := &fakeT{}
+	oopstest.RequireIs(ft, fmt.Errorf("wrapped: %w", errTest.Yeet()), errTest)
+	if ft.failed {
+		t.Fatalf("unexpected failure: %s", ft.message)
+	}
+
+	ft = &fakeT{}
+	oopstest.RequireIs(ft, errItem.Yeet(), errTest)
+	if !ft.failed || !strings.Contains(ft.message, `oops.Define("code", "oopstest.test")`) {
+		t.Fatalf("expected failure naming the definition, got %q", ft.message)
+	}
+
+	ft = &fakeT{}
+	oopstest.RequireIs(ft, nil, errTest)
+	if !ft.failed {
+		t.Fatal("expected failure on nil error")
+	}
+}
+
+func TestRequireNested(t *testing.T) {
+	t.Parallel()
+
+	ft := &fakeT{}
+	nested := oopstest.RequireNested(ft, collected(), errItem, "items[1]")
+	if ft.failed || nested == nil || nested.Explanation() != "invalid" {
+		t.Fatalf("expected nested error, got %v (%s)", nested, ft.message)
+	}
+
+	ft = &fakeT{}
+	oopstest.RequireNested(ft, collected(), errItem, "items[3]")
+	if !ft.failed || !strings.Contains(ft.message, "path: items[2]") {
+		t.Fatalf("expected failure with the error tree, got %q", ft.message)
+	}
+}
+
+func TestRequireProps(t *testing.T) {
+	t.Parallel()
+
+	err := errItem.Yeet().Set("id", "abc")
+
+	ft := &fakeT{}
+	oopstest.RequireProps(ft, err, map[string]any{"status": 400, "id": "abc"})
+	if ft.failed {
+		t.Fatalf("unexpected failure: %s", ft.message)
+	}
+
+	ft = &fakeT{}
+	oopstest.RequireProps(ft, err, map[string]any{"status": int64(400), "missing": true})
+	if !ft.failed ||
+		!strings.Contains(ft.message, "status: got int(400), want int64(400)") ||
+		!strings.Contains(ft.message, "missing: missing, want bool(true)") {
+		t.Fatalf("unexpected failure message: %q", ft.message)
+	}
+
+	ft = &fakeT{}
+	oopstest.RequireProps(ft, errors.New("plain"), map[string]any{})
+	if !ft.failed {
+		t.Fatal("expected failure on foreign error")
+	}
+}
+
+func TestRequireExplanation(t *testing.T) {
+	t.Parallel()
+
+	err := errTest.Yeetf("loading %s", "config")
+	err.Explainf("retrying")
+
+	ft := &fakeT{}
+	oopstest.RequireExplanation(ft, err, "config, retrying")
+	if ft.failed {
+		t.Fatalf("unexpected failure: %s", ft.message)
+	}
+
+	ft = &fakeT{}
+	oopstest.RequireExplanation(ft, err, "saving")
+	if !ft.failed {
+		t.Fatal("expected failure")
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	snapshot := oopstest.Snapshot(errTraced.Wrap(collected()))
+	if strings.Contains(snapshot, ".go:") || strings.Contains(snapshot, "0x") {
+		t.Fatalf("expected scrubbed traces, got:\n%s", snapshot)
+	}
+
+	if !strings.Contains(snapshot, "\t\tTestSnapshot\n") {
+		t.Fatalf("expected function names in traces, got:\n%s", snapshot)
+	}
+
+	if strings.Contains(snapshot, "tRunner") || strings.Contains(snapshot, "goexit") {
+		t.Fatalf("expected testing and runtime frames to be removed, got:\n%s", snapshot)
+	}
+}
+
+func TestRequireGolden(t *testing.T) {
+	t.Parallel()
+
+	oopstest.RequireGolden(t, errTraced.Wrap(collected()).Set("id", 7), filepath.Join("testdata", "collected.golden"))
+
+	if os.Getenv(oopstest.EnvUpdate) != "" {
+		return
+	}
+
+	path := filepath.Join(t.TempDir(), "different.golden")
+	if err := os.WriteFile(path, []byte(oopstest.Snapshot(errTest.Yeet())), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ft := &fakeT{}
+	oopstest.RequireGolden(ft, errTest.Yeetf("changed"), path)
+	if !ft.failed || !strings.Contains(ft.message, "+ \texplanation: changed") {
+		t.Fatalf("expected failure with diff, got %q", ft.message)
+	}
+
+	ft = &fakeT{}
+	oopstest.RequireGolden(ft, errTest.Yeet(), filepath.Join(t.TempDir(), "missing.golden"))
+	if !ft.failed || !strings.Contains(ft.message, "does not exist") {
+		t.Fatalf("expected failure on missing golden file, got %q", ft.message)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	diff := oopstest.Diff("a\nb\nc\n", "a\nx\nc\n")
+	if diff != "  a\n- b\n+ x\n  c\n" {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+}